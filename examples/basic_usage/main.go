@@ -268,7 +268,7 @@ func createCheckoutSession(ctx context.Context, client *bagelpay.BagelPayClient,
 
 // listProducts lists all products
 func listProducts(ctx context.Context, client *bagelpay.BagelPayClient) error {
-	response, err := client.ListProducts(ctx, 1, 5)
+	response, err := client.ListProducts(ctx, bagelpay.ProductFilter{}, 1, 5)
 	if err != nil {
 		return err
 	}
@@ -302,7 +302,7 @@ func listProducts(ctx context.Context, client *bagelpay.BagelPayClient) error {
 
 		// Check if it's a subscription product and get billing cycle
 		priceDisplay := fmt.Sprintf("%.2f %s", price, currency)
-		if product.BillingType != nil && *product.BillingType == "subscription" {
+		if product.IsSubscription() {
 			if product.RecurringInterval != nil && *product.RecurringInterval != "" {
 				priceDisplay += fmt.Sprintf(" / %s", *product.RecurringInterval)
 			}
@@ -349,7 +349,7 @@ func getProductDetails(ctx context.Context, client *bagelpay.BagelPayClient, pro
 
 // listCustomers lists customers
 func listCustomers(ctx context.Context, client *bagelpay.BagelPayClient) error {
-	response, err := client.ListCustomers(ctx, 1, 10) // pageNum=1, pageSize=10
+	response, err := client.ListCustomers(ctx, bagelpay.CustomerFilter{}, 1, 10) // pageNum=1, pageSize=10
 	if err != nil {
 		return err
 	}
@@ -404,7 +404,7 @@ func listCustomers(ctx context.Context, client *bagelpay.BagelPayClient) error {
 
 // listRecentTransactions lists recent transactions
 func listRecentTransactions(ctx context.Context, client *bagelpay.BagelPayClient) error {
-	response, err := client.ListTransactions(ctx, 1, 10) // pageNum=1, pageSize=10
+	response, err := client.ListTransactions(ctx, bagelpay.TransactionFilter{}, 1, 10) // pageNum=1, pageSize=10
 	if err != nil {
 		return err
 	}
@@ -435,7 +435,7 @@ func listRecentTransactions(ctx context.Context, client *bagelpay.BagelPayClient
 
 		txType := "N/A"
 		if transaction.Type != nil {
-			txType = *transaction.Type
+			txType = string(*transaction.Type)
 		}
 
 		fmt.Printf("   %d. ID: %s, Amount: %.2f %s, Type: %s\n",
@@ -450,7 +450,7 @@ func updateProduct(ctx context.Context, client *bagelpay.BagelPayClient) error {
 	fmt.Println("\n=== Example 6: Update Product ===")
 
 	// First, get a product to update (using the first product from our list)
-	response, err := client.ListProducts(ctx, 1, 1)
+	response, err := client.ListProducts(ctx, bagelpay.ProductFilter{}, 1, 1)
 	if err != nil {
 		fmt.Printf("Error listing products: %v\n", err)
 		return err
@@ -547,7 +547,7 @@ func archiveProduct(ctx context.Context, client *bagelpay.BagelPayClient) error
 	fmt.Println("\n=== Example 7: Archive Product ===")
 
 	// First, get a product to archive (using the first product from our list)
-	response, err := client.ListProducts(ctx, 1, 1)
+	response, err := client.ListProducts(ctx, bagelpay.ProductFilter{}, 1, 1)
 	if err != nil {
 		fmt.Printf("Error listing products: %v\n", err)
 		return err
@@ -612,7 +612,7 @@ func unarchiveProduct(ctx context.Context, client *bagelpay.BagelPayClient) erro
 	fmt.Println("\n=== Example 8: Unarchive Product ===")
 
 	// First, get an archived product to unarchive
-	response, err := client.ListProducts(ctx, 1, 10)
+	response, err := client.ListProducts(ctx, bagelpay.ProductFilter{}, 1, 10)
 	if err != nil {
 		fmt.Printf("Error listing products: %v\n", err)
 		return err
@@ -684,7 +684,7 @@ func listSubscriptions(ctx context.Context, client *bagelpay.BagelPayClient) err
 	fmt.Println("\n=== Example 9: List Subscriptions ===")
 
 	// List subscriptions with pagination
-	response, err := client.ListSubscriptions(ctx, 1, 3)
+	response, err := client.ListSubscriptions(ctx, bagelpay.SubscriptionFilter{}, 1, 3)
 	if err != nil {
 		fmt.Printf("Error listing subscriptions: %v\n", err)
 		return err
@@ -709,7 +709,7 @@ func listSubscriptions(ctx context.Context, client *bagelpay.BagelPayClient) err
 
 		status := "N/A"
 		if subscription.Status != nil {
-			status = *subscription.Status
+			status = string(*subscription.Status)
 		}
 		fmt.Printf("Status: %s\n", status)
 
@@ -760,7 +760,7 @@ func getSubscriptionDetails(ctx context.Context, client *bagelpay.BagelPayClient
 	fmt.Println("\n=== Example 10: Get Subscription Details ===")
 
 	// First, get a subscription ID from the list
-	response, err := client.ListSubscriptions(ctx, 1, 1)
+	response, err := client.ListSubscriptions(ctx, bagelpay.SubscriptionFilter{}, 1, 1)
 	if err != nil {
 		fmt.Printf("Error listing subscriptions: %v\n", err)
 		return err
@@ -795,7 +795,7 @@ func getSubscriptionDetails(ctx context.Context, client *bagelpay.BagelPayClient
 
 	status := "N/A"
 	if subscription.Status != nil {
-		status = *subscription.Status
+		status = string(*subscription.Status)
 	}
 	fmt.Printf("Status: %s\n", status)
 
@@ -857,7 +857,7 @@ func cancelSubscription(ctx context.Context, client *bagelpay.BagelPayClient) er
 	fmt.Println("\n=== Example 11: Cancel Subscription ===")
 
 	// First, get an active subscription to cancel
-	response, err := client.ListSubscriptions(ctx, 1, 10)
+	response, err := client.ListSubscriptions(ctx, bagelpay.SubscriptionFilter{}, 1, 10)
 	if err != nil {
 		fmt.Printf("Error listing subscriptions: %v\n", err)
 		return err
@@ -871,7 +871,7 @@ func cancelSubscription(ctx context.Context, client *bagelpay.BagelPayClient) er
 	// Find an active subscription
 	var subscriptionToCancel *bagelpay.Subscription
 	for _, subscription := range response.Items {
-		if subscription.Status != nil && *subscription.Status == "active" {
+		if subscription.IsActive() {
 			subscriptionToCancel = &subscription
 			break
 		}
@@ -888,7 +888,9 @@ func cancelSubscription(ctx context.Context, client *bagelpay.BagelPayClient) er
 	}
 
 	// Cancel the subscription
-	cancelledSubscription, err := client.CancelSubscription(ctx, *subscriptionToCancel.SubscriptionID)
+	cancelledSubscription, err := client.CancelSubscription(ctx, *subscriptionToCancel.SubscriptionID, bagelpay.CancelSubscriptionOptions{
+		AtPeriodEnd: true,
+	})
 	if err != nil {
 		fmt.Printf("Error cancelling subscription: %v\n", err)
 		return err
@@ -905,7 +907,7 @@ func cancelSubscription(ctx context.Context, client *bagelpay.BagelPayClient) er
 
 	status := "N/A"
 	if cancelledSubscription.Status != nil {
-		status = *cancelledSubscription.Status
+		status = string(*cancelledSubscription.Status)
 	}
 	fmt.Printf("Status: %s\n", status)
 
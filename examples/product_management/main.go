@@ -141,7 +141,7 @@ func createSubscriptionProduct(ctx context.Context, client *bagelpay.BagelPayCli
 
 // listAllProducts lists all products
 func listAllProducts(ctx context.Context, client *bagelpay.BagelPayClient) error {
-	response, err := client.ListProducts(ctx, 1, 5)
+	response, err := client.ListProducts(ctx, bagelpay.ProductFilter{}, 1, 5)
 	if err != nil {
 		return err
 	}
@@ -182,7 +182,7 @@ func listAllProducts(ctx context.Context, client *bagelpay.BagelPayClient) error
 
 		// Check if it's a subscription product and add recurring interval
 		priceDisplay := fmt.Sprintf("%.2f %s", price, currency)
-		if product.BillingType != nil && *product.BillingType == "subscription" && product.RecurringInterval != nil && *product.RecurringInterval != "" {
+		if product.IsSubscription() && product.RecurringInterval != nil && *product.RecurringInterval != "" {
 			priceDisplay += fmt.Sprintf(" (%s)", *product.RecurringInterval)
 		}
 		fmt.Printf("      Price: %s\n", priceDisplay)
@@ -217,7 +217,7 @@ func getProductDetails(ctx context.Context, client *bagelpay.BagelPayClient, pro
 	if product.Price != nil && product.Currency != nil {
 		priceDisplay := fmt.Sprintf("%.2f %s", *product.Price, *product.Currency)
 		// Check if it's a subscription product and add recurring interval
-		if product.BillingType != nil && *product.BillingType == "subscription" && product.RecurringInterval != nil && *product.RecurringInterval != "" {
+		if product.IsSubscription() && product.RecurringInterval != nil && *product.RecurringInterval != "" {
 			priceDisplay += fmt.Sprintf(" (%s)", *product.RecurringInterval)
 		}
 		fmt.Printf("   Price: %s\n", priceDisplay)
@@ -87,7 +87,7 @@ func main() {
 
 // listAllSubscriptions lists all subscriptions
 func listAllSubscriptions(ctx context.Context, client *bagelpay.BagelPayClient) ([]*bagelpay.Subscription, error) {
-	response, err := client.ListSubscriptions(ctx, 1, 5) // pageNum=1, pageSize=50
+	response, err := client.ListSubscriptions(ctx, bagelpay.SubscriptionFilter{}, 1, 5) // pageNum=1, pageSize=50
 	if err != nil {
 		return nil, err
 	}
@@ -176,12 +176,14 @@ func getSubscriptionDetails(ctx context.Context, client *bagelpay.BagelPayClient
 
 // cancelSubscription cancels a subscription
 func cancelSubscription(ctx context.Context, client *bagelpay.BagelPayClient, subscriptionID string) error {
-	subscription, err := client.CancelSubscription(ctx, subscriptionID)
+	subscription, err := client.CancelSubscription(ctx, subscriptionID, bagelpay.CancelSubscriptionOptions{
+		AtPeriodEnd: true,
+	})
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("✅ Subscription canceled successfully!\n")
+	fmt.Printf("✅ Subscription cancelled successfully!\n")
 	if subscription.SubscriptionID != nil {
 		fmt.Printf("   ID: %s\n", *subscription.SubscriptionID)
 	}
@@ -198,7 +200,7 @@ func cancelSubscription(ctx context.Context, client *bagelpay.BagelPayClient, su
 
 // listAllCustomers lists all customers
 func listAllCustomers(ctx context.Context, client *bagelpay.BagelPayClient) ([]*bagelpay.CustomerData, error) {
-	response, err := client.ListCustomers(ctx, 1, 5) // pageNum=1, pageSize=50
+	response, err := client.ListCustomers(ctx, bagelpay.CustomerFilter{}, 1, 5) // pageNum=1, pageSize=50
 	if err != nil {
 		return nil, err
 	}
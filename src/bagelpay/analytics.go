@@ -0,0 +1,150 @@
+package bagelpay
+
+import (
+	"math"
+	"time"
+)
+
+// MRROptions controls which subscriptions CalculateMRR counts
+type MRROptions struct {
+	// IncludePaused counts paused subscriptions as if they were active
+	IncludePaused bool
+}
+
+// monthsPerInterval is the length of each recurring interval in months
+var monthsPerInterval = map[string]float64{
+	IntervalDaily:   12.0 / 365.0,
+	IntervalWeekly:  12.0 / 52.0,
+	IntervalMonthly: 1,
+	Interval3Months: 3,
+	Interval6Months: 6,
+	IntervalYearly:  12,
+}
+
+// CalculateMRR returns the monthly recurring revenue of the subscriptions: the sum of
+// each counted subscription's Amount normalised to a month by its RecurringInterval
+// (quarterly amounts are divided by 3, yearly by 12, and so on).
+//
+// Active and past-due subscriptions are counted. Trialing subscriptions are excluded
+// because they have not paid yet, as are cancelled ones and those with no amount or an
+// unknown interval. Paused subscriptions are excluded unless opts.IncludePaused is set.
+// Amounts are summed as-is, so pass subscriptions in a single currency.
+func CalculateMRR(subscriptions []Subscription, opts ...MRROptions) float64 {
+	var options MRROptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	var mrr float64
+	for _, s := range subscriptions {
+		if amount, ok := monthlyAmount(s, options); ok {
+			mrr += amount
+		}
+	}
+	return mrr
+}
+
+// CalculateARR returns the annual recurring revenue of the subscriptions, which is
+// twelve times CalculateMRR with the same options
+func CalculateARR(subscriptions []Subscription, opts ...MRROptions) float64 {
+	return CalculateMRR(subscriptions, opts...) * 12
+}
+
+// monthlyAmount returns the subscription's amount normalised to a month, and whether
+// the subscription counts towards recurring revenue at all
+func monthlyAmount(s Subscription, options MRROptions) (float64, bool) {
+	if s.Status == nil || s.Amount == nil || s.RecurringInterval == nil {
+		return 0, false
+	}
+	switch *s.Status {
+	case SubscriptionStatusActive, SubscriptionStatusPastDue:
+	case SubscriptionStatusPaused:
+		if !options.IncludePaused {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+
+	months, ok := monthsPerInterval[*s.RecurringInterval]
+	if !ok {
+		return 0, false
+	}
+	return *s.Amount / months, true
+}
+
+// revenueByID maps the ID of every subscription counted by CalculateMRR with default
+// options, so excluding paused ones, to its monthly amount
+func revenueByID(subscriptions []Subscription) map[string]float64 {
+	revenue := make(map[string]float64, len(subscriptions))
+	for _, s := range subscriptions {
+		if s.SubscriptionID == nil {
+			continue
+		}
+		if amount, ok := monthlyAmount(s, MRROptions{}); ok {
+			revenue[*s.SubscriptionID] += amount
+		}
+	}
+	return revenue
+}
+
+// churnPeriod is the period CalculateChurnRate normalises to
+const churnPeriod = 30 * 24 * time.Hour
+
+// CalculateChurnRate returns the fraction of subscriptions lost between two snapshots,
+// normalised to a 30-day month.
+//
+// A subscription is retained when it counts towards MRR (see CalculateMRR) in the start
+// snapshot and still does, matched by SubscriptionID, in the end snapshot. Paused
+// subscriptions do not count, so one paused during the period is churned. The raw churn is
+// lost / counted-at-start; new subscriptions in the end snapshot are ignored. The raw churn
+// over period is converted to a monthly rate assuming a constant rate of loss:
+//
+//	monthly = 1 - (1 - raw)^(30 days / period)
+//
+// A period of zero or less returns the raw churn. It returns 0 when no subscription counts
+// at the start.
+func CalculateChurnRate(startSubscriptions []Subscription, endSubscriptions []Subscription, period time.Duration) float64 {
+	start := revenueByID(startSubscriptions)
+	if len(start) == 0 {
+		return 0
+	}
+	end := revenueByID(endSubscriptions)
+
+	lost := 0
+	for id := range start {
+		if _, ok := end[id]; !ok {
+			lost++
+		}
+	}
+
+	churn := float64(lost) / float64(len(start))
+	if period <= 0 || period == churnPeriod {
+		return churn
+	}
+	return 1 - math.Pow(1-churn, float64(churnPeriod)/float64(period))
+}
+
+// CalculateNetRevenueRetention returns the recurring revenue kept from an existing cohort
+// between two snapshots, as a fraction of the starting revenue.
+//
+// The cohort is the subscriptions counted by CalculateMRR in the start snapshot. Their MRR in
+// the end snapshot, matched by SubscriptionID, is divided by their MRR at the start, so
+// upgrades (expansion) raise the figure and downgrades (contraction) and cancellations (churn)
+// lower it; subscriptions new in the end snapshot are ignored. Paused subscriptions do
+// not count, so pausing lowers it like a cancellation. A result above 1 means the
+// cohort grew. It returns 0 when the starting MRR is zero.
+func CalculateNetRevenueRetention(startSubscriptions, endSubscriptions []Subscription) float64 {
+	start := revenueByID(startSubscriptions)
+	end := revenueByID(endSubscriptions)
+
+	var startMRR, retainedMRR float64
+	for id, amount := range start {
+		startMRR += amount
+		retainedMRR += end[id]
+	}
+	if startMRR == 0 {
+		return 0
+	}
+	return retainedMRR / startMRR
+}
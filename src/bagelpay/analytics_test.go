@@ -0,0 +1,174 @@
+package bagelpay
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// sub builds a subscription for the analytics tests; empty fields are left nil
+func sub(id string, status SubscriptionStatus, amount float64, interval string) Subscription {
+	s := Subscription{Amount: Float64Ptr(amount)}
+	if id != "" {
+		s.SubscriptionID = StringPtr(id)
+	}
+	if status != "" {
+		s.Status = &status
+	}
+	if interval != "" {
+		s.RecurringInterval = StringPtr(interval)
+	}
+	return s
+}
+
+// approxEqual reports whether a and b differ by less than a rounding error
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestCalculateMRR(t *testing.T) {
+	active := SubscriptionStatusActive
+	tests := []struct {
+		name          string
+		subscriptions []Subscription
+		opts          []MRROptions
+		want          float64
+	}{
+		{"none", nil, nil, 0},
+		{"daily", []Subscription{sub("", active, 1, IntervalDaily)}, nil, 365.0 / 12.0},
+		{"weekly", []Subscription{sub("", active, 10, IntervalWeekly)}, nil, 10 * 52.0 / 12.0},
+		{"monthly", []Subscription{sub("", active, 25, IntervalMonthly)}, nil, 25},
+		{"quarterly", []Subscription{sub("", active, 90, Interval3Months)}, nil, 30},
+		{"half-yearly", []Subscription{sub("", active, 60, Interval6Months)}, nil, 10},
+		{"yearly", []Subscription{sub("", active, 120, IntervalYearly)}, nil, 10},
+		{"past due counts", []Subscription{sub("", SubscriptionStatusPastDue, 20, IntervalMonthly)}, nil, 20},
+		{"trialing excluded", []Subscription{sub("", SubscriptionStatusTrialing, 20, IntervalMonthly)}, nil, 0},
+		{"cancelled excluded", []Subscription{sub("", SubscriptionStatusCancelled, 20, IntervalMonthly)}, nil, 0},
+		{"paused excluded by default", []Subscription{sub("", SubscriptionStatusPaused, 20, IntervalMonthly)}, nil, 0},
+		{"paused included", []Subscription{sub("", SubscriptionStatusPaused, 20, IntervalMonthly)}, []MRROptions{{IncludePaused: true}}, 20},
+		{"unknown interval skipped", []Subscription{sub("", active, 20, "fortnightly")}, nil, 0},
+		{"nil status skipped", []Subscription{sub("", "", 20, IntervalMonthly)}, nil, 0},
+		{"nil interval skipped", []Subscription{sub("", active, 20, "")}, nil, 0},
+		{"nil amount skipped", []Subscription{{Status: &active, RecurringInterval: StringPtr(IntervalMonthly)}}, nil, 0},
+		{
+			"mixed",
+			[]Subscription{
+				sub("", active, 10, IntervalMonthly),
+				sub("", active, 120, IntervalYearly),
+				sub("", SubscriptionStatusTrialing, 99, IntervalMonthly),
+			},
+			nil,
+			20,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mrr := CalculateMRR(tt.subscriptions, tt.opts...)
+			if !approxEqual(mrr, tt.want) {
+				t.Errorf("CalculateMRR = %v, want %v", mrr, tt.want)
+			}
+			if arr := CalculateARR(tt.subscriptions, tt.opts...); !approxEqual(arr, 12*mrr) {
+				t.Errorf("CalculateARR = %v, want 12 * %v", arr, mrr)
+			}
+		})
+	}
+}
+
+func TestCalculateChurnRate(t *testing.T) {
+	active := SubscriptionStatusActive
+	start := []Subscription{
+		sub("a", active, 10, IntervalMonthly),
+		sub("b", active, 10, IntervalMonthly),
+		sub("c", active, 10, IntervalMonthly),
+		sub("d", active, 10, IntervalMonthly),
+	}
+	// b is cancelled and c is gone; e is new and does not count
+	end := []Subscription{
+		sub("a", active, 10, IntervalMonthly),
+		sub("b", SubscriptionStatusCancelled, 10, IntervalMonthly),
+		sub("d", active, 10, IntervalMonthly),
+		sub("e", active, 10, IntervalMonthly),
+	}
+
+	tests := []struct {
+		name   string
+		start  []Subscription
+		end    []Subscription
+		period time.Duration
+		want   float64
+	}{
+		{"30 days is the raw churn", start, end, 30 * 24 * time.Hour, 0.5},
+		{"60 days compounds", start, end, 60 * 24 * time.Hour, 1 - math.Sqrt(0.5)},
+		{"15 days compounds", start, end, 15 * 24 * time.Hour, 0.75},
+		{"zero period is the raw churn", start, end, 0, 0.5},
+		{"negative period is the raw churn", start, end, -time.Hour, 0.5},
+		{"nothing lost", start, start, 30 * 24 * time.Hour, 0},
+		{"empty start", nil, end, 30 * 24 * time.Hour, 0},
+		{"only trialing at start", []Subscription{sub("t", SubscriptionStatusTrialing, 10, IntervalMonthly)}, nil, 30 * 24 * time.Hour, 0},
+		{
+			"paused counts as churned",
+			start[:1],
+			[]Subscription{sub("a", SubscriptionStatusPaused, 10, IntervalMonthly)},
+			30 * 24 * time.Hour,
+			1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CalculateChurnRate(tt.start, tt.end, tt.period); !approxEqual(got, tt.want) {
+				t.Errorf("CalculateChurnRate = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateNetRevenueRetention(t *testing.T) {
+	active := SubscriptionStatusActive
+	start := []Subscription{
+		sub("expand", active, 100, IntervalMonthly),
+		sub("contract", active, 100, IntervalMonthly),
+		sub("churn", active, 100, IntervalMonthly),
+		sub("steady", active, 1200, IntervalYearly),
+	}
+
+	tests := []struct {
+		name string
+		end  []Subscription
+		want float64
+	}{
+		{"unchanged", start, 1},
+		{
+			"expansion, contraction, and churn",
+			[]Subscription{
+				sub("expand", active, 250, IntervalMonthly),
+				sub("contract", active, 50, IntervalMonthly),
+				sub("churn", SubscriptionStatusCancelled, 100, IntervalMonthly),
+				sub("steady", active, 1200, IntervalYearly),
+				sub("new", active, 1000, IntervalMonthly),
+			},
+			(250.0 + 50 + 0 + 100) / 400,
+		},
+		{
+			"expansion above 1",
+			[]Subscription{
+				sub("expand", active, 500, IntervalMonthly),
+				sub("contract", active, 100, IntervalMonthly),
+				sub("churn", active, 100, IntervalMonthly),
+				sub("steady", active, 1200, IntervalYearly),
+			},
+			800.0 / 400,
+		},
+		{"all churned", nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CalculateNetRevenueRetention(start, tt.end); !approxEqual(got, tt.want) {
+				t.Errorf("CalculateNetRevenueRetention = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := CalculateNetRevenueRetention(nil, start); got != 0 {
+		t.Errorf("CalculateNetRevenueRetention with no starting revenue = %v, want 0", got)
+	}
+}
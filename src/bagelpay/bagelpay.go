@@ -74,6 +74,14 @@ const (
 	DefaultTimeout = 30 * time.Second
 	// DefaultUserAgent is the default user agent string
 	DefaultUserAgent = "BagelPay-Go-SDK/1.0.0"
+	// DefaultMaxRetries is the default number of retries for rate-limited requests
+	DefaultMaxRetries = 3
+	// DefaultRetryBaseDelay is the default base delay for retry backoff
+	DefaultRetryBaseDelay = 500 * time.Millisecond
+	// DefaultRetryMaxDelay is the default upper bound for a single retry delay
+	DefaultRetryMaxDelay = 30 * time.Second
+	// DefaultCheckoutLocale is the language CreateCheckout requests when Locale is nil
+	DefaultCheckoutLocale = "en"
 )
 
 // NewDefaultClient creates a new BagelPay client with default configuration
@@ -0,0 +1,125 @@
+package bagelpay
+
+import "strconv"
+
+// CheckoutRequestBuilder assembles a CheckoutRequest with chainable setters
+type CheckoutRequestBuilder struct {
+	request CheckoutRequest
+}
+
+// NewCheckoutRequestBuilder creates a builder for a checkout of the given product
+func NewCheckoutRequestBuilder(productID string) *CheckoutRequestBuilder {
+	return &CheckoutRequestBuilder{
+		request: CheckoutRequest{ProductID: productID},
+	}
+}
+
+// WithCustomer sets the customer email
+func (b *CheckoutRequestBuilder) WithCustomer(email string) *CheckoutRequestBuilder {
+	b.request.Customer = &Customer{Email: email}
+	return b
+}
+
+// WithCustomerDetails sets the customer, pre-filling the checkout form with any
+// name, phone, country, or address given
+func (b *CheckoutRequestBuilder) WithCustomerDetails(customer Customer) *CheckoutRequestBuilder {
+	b.request.Customer = &customer
+	return b
+}
+
+// WithSuccessURL sets the URL the customer is redirected to after payment
+func (b *CheckoutRequestBuilder) WithSuccessURL(u string) *CheckoutRequestBuilder {
+	b.request.SuccessURL = StringPtr(u)
+	return b
+}
+
+// WithCancelURL sets the URL the customer is redirected to when they abandon the checkout
+func (b *CheckoutRequestBuilder) WithCancelURL(u string) *CheckoutRequestBuilder {
+	b.request.CancelURL = StringPtr(u)
+	return b
+}
+
+// WithCustomerIPAddress sets the customer's IP address for tax jurisdiction detection
+func (b *CheckoutRequestBuilder) WithCustomerIPAddress(ip string) *CheckoutRequestBuilder {
+	b.request.CustomerIPAddress = StringPtr(ip)
+	return b
+}
+
+// WithShippingCollection asks the customer for a shipping address, restricted to the
+// given ISO 3166-1 alpha-2 country codes when any are given
+func (b *CheckoutRequestBuilder) WithShippingCollection(countries ...string) *CheckoutRequestBuilder {
+	b.request.CollectShipping = BoolPtr(true)
+	b.request.ShippingCountries = append([]string(nil), countries...)
+	return b
+}
+
+// WithPhoneCollection asks the customer for a phone number
+func (b *CheckoutRequestBuilder) WithPhoneCollection() *CheckoutRequestBuilder {
+	b.request.CollectPhone = BoolPtr(true)
+	return b
+}
+
+// WithLocale sets the BCP 47 language tag, such as "fr" or "ja", the hosted checkout
+// page is rendered in. Without it CreateCheckout requests DefaultCheckoutLocale ("en").
+func (b *CheckoutRequestBuilder) WithLocale(locale string) *CheckoutRequestBuilder {
+	b.request.Locale = StringPtr(locale)
+	return b
+}
+
+// WithExpiresInMinutes overrides how long the checkout link stays valid, from 5 minutes
+// to one day. The response's ExpiresOn reflects the custom expiry.
+func (b *CheckoutRequestBuilder) WithExpiresInMinutes(minutes int) *CheckoutRequestBuilder {
+	b.request.ExpiresInMinutes = IntPtr(minutes)
+	return b
+}
+
+// WithPaymentMethodToken charges a saved payment method, such as the ID of a
+// PaymentMethod from ListPaymentMethods, directly instead of redirecting the customer
+// to the hosted page. The response's Status reports the outcome of the charge.
+func (b *CheckoutRequestBuilder) WithPaymentMethodToken(token string) *CheckoutRequestBuilder {
+	b.request.PaymentMethodToken = StringPtr(token)
+	return b
+}
+
+// WithUnits sets the number of units to purchase
+func (b *CheckoutRequestBuilder) WithUnits(n int) *CheckoutRequestBuilder {
+	b.request.Units = StringPtr(strconv.Itoa(n))
+	return b
+}
+
+// WithRequestID sets the request ID used for idempotency
+func (b *CheckoutRequestBuilder) WithRequestID(id string) *CheckoutRequestBuilder {
+	b.request.RequestID = StringPtr(id)
+	return b
+}
+
+// WithMetadata adds a single metadata key-value pair
+func (b *CheckoutRequestBuilder) WithMetadata(k string, v interface{}) *CheckoutRequestBuilder {
+	if b.request.Metadata == nil {
+		b.request.Metadata = make(map[string]interface{})
+	}
+	b.request.Metadata[k] = v
+	return b
+}
+
+// WithIdempotencyKey sets the Idempotency-Key header sent with the request
+func (b *CheckoutRequestBuilder) WithIdempotencyKey(key string) *CheckoutRequestBuilder {
+	b.request.IdempotencyKey = StringPtr(key)
+	return b
+}
+
+// Build returns the assembled CheckoutRequest. Its slices and maps are copies, so
+// later calls on the builder do not change requests already built.
+func (b *CheckoutRequestBuilder) Build() CheckoutRequest {
+	request := b.request
+	if b.request.ShippingCountries != nil {
+		request.ShippingCountries = append([]string(nil), b.request.ShippingCountries...)
+	}
+	if b.request.Metadata != nil {
+		request.Metadata = make(map[string]interface{}, len(b.request.Metadata))
+		for k, v := range b.request.Metadata {
+			request.Metadata[k] = v
+		}
+	}
+	return request
+}
@@ -0,0 +1,82 @@
+package bagelpay
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckoutRequestBuilder(t *testing.T) {
+	got := NewCheckoutRequestBuilder("prod_1").
+		WithCustomer("ada@example.com").
+		WithSuccessURL("https://shop.example.com/thanks").
+		WithCancelURL("https://shop.example.com/cart").
+		WithCustomerIPAddress("203.0.113.7").
+		WithShippingCollection("GB", "IE").
+		WithPhoneCollection().
+		WithLocale("fr").
+		WithExpiresInMinutes(45).
+		WithPaymentMethodToken("pm_1").
+		WithUnits(3).
+		WithRequestID("req_1").
+		WithMetadata("order", "A-1").
+		WithMetadata("seats", 3).
+		WithIdempotencyKey("idem_1").
+		Build()
+
+	want := CheckoutRequest{
+		ProductID:          "prod_1",
+		Customer:           &Customer{Email: "ada@example.com"},
+		RequestID:          StringPtr("req_1"),
+		Units:              StringPtr("3"),
+		SuccessURL:         StringPtr("https://shop.example.com/thanks"),
+		CancelURL:          StringPtr("https://shop.example.com/cart"),
+		CustomerIPAddress:  StringPtr("203.0.113.7"),
+		CollectShipping:    BoolPtr(true),
+		ShippingCountries:  []string{"GB", "IE"},
+		CollectPhone:       BoolPtr(true),
+		Locale:             StringPtr("fr"),
+		ExpiresInMinutes:   IntPtr(45),
+		PaymentMethodToken: StringPtr("pm_1"),
+		Metadata:           map[string]interface{}{"order": "A-1", "seats": 3},
+		IdempotencyKey:     StringPtr("idem_1"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Build() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestCheckoutRequestBuilderWithCustomerDetails(t *testing.T) {
+	details := Customer{Email: "ada@example.com", Name: StringPtr("Ada"), Country: StringPtr("GB")}
+	got := NewCheckoutRequestBuilder("prod_1").WithCustomerDetails(details).Build()
+	if got.Customer == nil || !reflect.DeepEqual(*got.Customer, details) {
+		t.Errorf("Customer = %+v, want %+v", got.Customer, details)
+	}
+}
+
+func TestCheckoutRequestBuilderBuildsIndependentRequests(t *testing.T) {
+	countries := []string{"GB", "IE"}
+	builder := NewCheckoutRequestBuilder("prod_1").
+		WithShippingCollection(countries...).
+		WithMetadata("order", "A-1")
+	first := builder.Build()
+
+	// Neither the caller's slice nor later builder calls may reach the first request
+	countries[0] = "US"
+	builder.WithMetadata("order", "B-2").WithShippingCollection("FR").WithLocale("de")
+	second := builder.Build()
+	second.ShippingCountries[0] = "ES"
+	second.Metadata["extra"] = true
+
+	if !reflect.DeepEqual(first.ShippingCountries, []string{"GB", "IE"}) {
+		t.Errorf("first ShippingCountries = %v, want [GB IE]", first.ShippingCountries)
+	}
+	if first.Metadata["order"] != "A-1" || len(first.Metadata) != 1 {
+		t.Errorf("first Metadata = %v, want order=A-1 only", first.Metadata)
+	}
+	if first.Locale != nil {
+		t.Errorf("first Locale = %q, want nil", *first.Locale)
+	}
+	if third := builder.Build(); third.ShippingCountries[0] != "FR" || third.Metadata["extra"] != nil {
+		t.Errorf("changing a built request changed the builder: %+v", third)
+	}
+}
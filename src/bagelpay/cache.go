@@ -0,0 +1,81 @@
+package bagelpay
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// cachedResponse is a GET response body stored with the ETag it was served with
+type cachedResponse struct {
+	ETag string
+	Body []byte
+}
+
+// responseCache keeps GET response bodies by URL so that unchanged resources are
+// revalidated with If-None-Match instead of downloaded again. It is installed by
+// ClientConfig.CacheEnabled; a nil cache does nothing. Entries are never evicted, so
+// the cache grows with the number of distinct URLs that return an ETag.
+type responseCache struct {
+	entries sync.Map // URL -> cachedResponse
+}
+
+// prepare asks the API to skip the body when the cached copy of a GET request is current
+func (rc *responseCache) prepare(req *http.Request) {
+	if rc == nil || req.Method != http.MethodGet {
+		return
+	}
+	if entry, ok := rc.entries.Load(req.URL.String()); ok {
+		req.Header.Set("If-None-Match", entry.(cachedResponse).ETag)
+	}
+}
+
+// resolve updates the cache with the response to req, the request makeRequest sent.
+// It is keyed on req rather than resp.Request, which transports and middlewares need
+// not set. A 304 Not Modified gets the cached body; a 200 body carrying an ETag is
+// cached once it has been read to the end.
+func (rc *responseCache) resolve(req *http.Request, resp *http.Response) error {
+	if rc == nil || req.Method != http.MethodGet {
+		return nil
+	}
+
+	key := req.URL.String()
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		entry, ok := rc.entries.Load(key)
+		if !ok {
+			return NewBagelPayError("received 304 Not Modified without a cached response", nil)
+		}
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(entry.(cachedResponse).Body))
+	case http.StatusOK:
+		etag := resp.Header.Get("ETag")
+		if etag == "" {
+			rc.entries.Delete(key)
+			return nil
+		}
+		resp.Body = &cachingBody{ReadCloser: resp.Body, store: func(body []byte) {
+			rc.entries.Store(key, cachedResponse{ETag: etag, Body: body})
+		}}
+	}
+	return nil
+}
+
+// cachingBody passes a response body to store once it has been read to the end, so
+// that a body cut short by the size limit is never cached
+type cachingBody struct {
+	io.ReadCloser
+	buf   bytes.Buffer
+	store func([]byte)
+}
+
+func (b *cachingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.buf.Write(p[:n])
+	if err == io.EOF && b.store != nil {
+		b.store(b.buf.Bytes())
+		b.store = nil
+	}
+	return n, err
+}
@@ -0,0 +1,92 @@
+package bagelpay
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+// etagServer serves the store with an ETag and answers 304 when it is sent back
+func etagServer(ifNoneMatch *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*ifNoneMatch = append(*ifNoneMatch, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"data":{"name":"Bagel Shop"}}`))
+	}
+}
+
+// dropRequest clears resp.Request, as a custom transport is free to do
+func dropRequest(next http.RoundTripper) http.RoundTripper {
+	return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := next.RoundTrip(req)
+		if resp != nil {
+			resp.Request = nil
+		}
+		return resp, err
+	})
+}
+
+func TestCacheServesBodyOnNotModified(t *testing.T) {
+	tests := []struct {
+		name        string
+		middlewares []func(http.RoundTripper) http.RoundTripper
+	}{
+		{"default transport", nil},
+		{"transport without resp.Request", []func(http.RoundTripper) http.RoundTripper{dropRequest}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ifNoneMatch []string
+			client := newTestClient(t, etagServer(&ifNoneMatch), func(c *ClientConfig) {
+				c.CacheEnabled = true
+				c.Middlewares = tt.middlewares
+			})
+
+			for i := 0; i < 2; i++ {
+				store, err := client.GetStoreInfo(context.Background())
+				if err != nil {
+					t.Fatalf("call %d: GetStoreInfo: %v", i+1, err)
+				}
+				if store.Name == nil || *store.Name != "Bagel Shop" {
+					t.Errorf("call %d: store name = %v, want Bagel Shop", i+1, store.Name)
+				}
+			}
+			if len(ifNoneMatch) != 2 || ifNoneMatch[0] != "" || ifNoneMatch[1] != `"v1"` {
+				t.Errorf("If-None-Match headers = %q, want [\"\" \"v1\"]", ifNoneMatch)
+			}
+		})
+	}
+}
+
+func TestCacheNotModifiedWithoutEntry(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}, func(c *ClientConfig) {
+		c.CacheEnabled = true
+	})
+
+	if _, err := client.GetStoreInfo(context.Background()); err == nil {
+		t.Fatal("GetStoreInfo succeeded on a 304 with nothing cached")
+	}
+}
+
+func TestCacheSkipsOversizedBody(t *testing.T) {
+	var ifNoneMatch []string
+	client := newTestClient(t, etagServer(&ifNoneMatch), func(c *ClientConfig) {
+		c.CacheEnabled = true
+		c.MaxResponseBodyBytes = 10
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetStoreInfo(context.Background()); err == nil {
+			t.Fatalf("call %d: GetStoreInfo accepted a body over the limit", i+1)
+		}
+	}
+	if ifNoneMatch[1] != "" {
+		t.Errorf("second call sent If-None-Match %q for a body that was never read in full", ifNoneMatch[1])
+	}
+}
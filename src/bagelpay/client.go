@@ -3,9 +3,13 @@ package bagelpay
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -13,6 +17,10 @@ import (
 	"time"
 )
 
+// DefaultMaxResponseBodyBytes is the largest response body the client reads unless
+// ClientConfig.MaxResponseBodyBytes says otherwise
+const DefaultMaxResponseBodyBytes int64 = 10 << 20
+
 // ClientConfig represents configuration options for BagelPayClient
 type ClientConfig struct {
 	// APIKey for authentication
@@ -21,21 +29,72 @@ type ClientConfig struct {
 	TestMode bool
 	// BaseURL is an optional custom base URL (overrides TestMode)
 	BaseURL string
-	// Timeout is the request timeout duration (default: 30 seconds)
+	// Timeout is the request timeout duration (default: 30 seconds).
+	// It only applies to calls whose context has no deadline of its own.
 	Timeout time.Duration
 	// HTTPClient is an optional custom HTTP client
 	HTTPClient *http.Client
+	// Retry configures automatic retries of rate-limited requests (default: DefaultRetryConfig())
+	Retry *RetryConfig
+	// TracerProvider is an optional OpenTelemetry trace.TracerProvider used to trace requests.
+	// It requires building the SDK with the "otel" build tag; Validate rejects any other
+	// value, and any provider at all in builds without the tag.
+	TracerProvider interface{}
+	// Logger is an optional structured logger for requests, responses, and retries (default: nil, no logging)
+	Logger *slog.Logger
+	// Middlewares wrap the HTTP transport; the first middleware is outermost
+	Middlewares []func(http.RoundTripper) http.RoundTripper
+	// AutoRequestID generates a UUID request ID for checkouts that have none (default: true)
+	AutoRequestID *bool
+	// Debug dumps every request and response, bodies included, to log.Default() with the
+	// API key redacted. Intended for development only (default: false)
+	Debug bool
+	// MaxResponseBodyBytes caps the size of a response body; larger responses fail with a
+	// BagelPayError instead of being read into memory (default: DefaultMaxResponseBodyBytes)
+	MaxResponseBodyBytes int64
+	// MaxIdleConns caps idle connections across all hosts (default: 100, as http.DefaultTransport).
+	// This and the other transport options below cannot be combined with HTTPClient.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost caps idle connections kept to the API host (default: 2)
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout is how long an idle connection stays in the pool (default: 90 seconds)
+	IdleConnTimeout time.Duration
+	// DisableKeepAlives opens a new connection for every request (default: false)
+	DisableKeepAlives bool
+	// TLSConfig replaces the transport's TLS configuration, e.g. to trust a corporate CA
+	TLSConfig *tls.Config
+	// ProxyURL routes requests through an http, https, or socks5 proxy
+	// (default: the HTTP_PROXY and HTTPS_PROXY environment variables)
+	ProxyURL string
+	// DisableHTTP2 restricts requests to HTTP/1.1, for proxies that mishandle HTTP/2 (default: false)
+	DisableHTTP2 bool
+	// CacheEnabled keeps GET responses that carry an ETag in memory and revalidates them
+	// with If-None-Match, so unchanged resources are not downloaded again (default: false)
+	CacheEnabled bool
 }
 
 // BagelPayClient provides access to the BagelPay API endpoints
 type BagelPayClient struct {
-	baseURL    string
-	apiKey     string
-	httpClient *http.Client
+	baseURL       string
+	apiKey        string
+	testMode      bool
+	httpClient    *http.Client
+	timeout       time.Duration
+	retry         RetryConfig
+	tracer        requestTracer
+	logger        *slog.Logger
+	autoRequestID bool
+	maxBodyBytes  int64
+	cache         *responseCache
 }
 
-// NewClient creates a new BagelPay API client
+// NewClient creates a new BagelPay API client.
+// It panics if the configuration is invalid; see ClientConfig.Validate.
 func NewClient(config ClientConfig) *BagelPayClient {
+	if err := config.Validate(); err != nil {
+		panic(fmt.Sprintf("bagelpay: invalid client configuration: %v", err))
+	}
+
 	// Determine base URL based on test mode
 	baseURL := config.BaseURL
 	if baseURL == "" {
@@ -47,25 +106,104 @@ func NewClient(config ClientConfig) *BagelPayClient {
 	}
 	baseURL = strings.TrimSuffix(baseURL, "/")
 
-	// Set default timeout
+	// Use provided HTTP client or create a new one. The timeout is enforced
+	// per request through the context so that callers can extend or shorten it.
 	timeout := config.Timeout
-	if timeout == 0 {
-		timeout = 30 * time.Second
-	}
-
-	// Use provided HTTP client or create a new one
 	httpClient := config.HTTPClient
 	if httpClient == nil {
-		httpClient = &http.Client{
-			Timeout: timeout,
+		httpClient = &http.Client{Transport: newTransport(config)}
+		if timeout == 0 {
+			timeout = 30 * time.Second
+		}
+	}
+
+	maxBodyBytes := config.MaxResponseBodyBytes
+	if maxBodyBytes == 0 {
+		maxBodyBytes = DefaultMaxResponseBodyBytes
+	}
+
+	// Wrap the transport with the configured middlewares. The debug middleware is
+	// innermost so that it logs requests exactly as they are sent.
+	middlewares := config.Middlewares
+	if config.Debug {
+		middlewares = append(middlewares[:len(middlewares):len(middlewares)], debugMiddleware(log.Default(), maxBodyBytes))
+	}
+	if len(middlewares) > 0 {
+		transport := httpClient.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
 		}
+		wrapped := *httpClient
+		wrapped.Transport = chainMiddlewares(transport, middlewares)
+		httpClient = &wrapped
+	}
+
+	// Use provided retry configuration or the defaults
+	retry := DefaultRetryConfig()
+	if config.Retry != nil {
+		retry = config.Retry.withDefaults()
+	}
+
+	var cache *responseCache
+	if config.CacheEnabled {
+		cache = &responseCache{}
 	}
 
 	return &BagelPayClient{
-		baseURL:    baseURL,
-		apiKey:     config.APIKey,
-		httpClient: httpClient,
+		baseURL:       baseURL,
+		apiKey:        config.APIKey,
+		testMode:      config.TestMode,
+		httpClient:    httpClient,
+		timeout:       timeout,
+		retry:         retry,
+		tracer:        newRequestTracer(config.TracerProvider),
+		logger:        config.Logger,
+		autoRequestID: config.AutoRequestID == nil || *config.AutoRequestID,
+		maxBodyBytes:  maxBodyBytes,
+		cache:         cache,
+	}
+}
+
+// IsTestMode reports whether the client was configured for test mode
+func (c *BagelPayClient) IsTestMode() bool {
+	return c.testMode
+}
+
+// IsLiveMode reports whether the client was configured for live mode
+func (c *BagelPayClient) IsLiveMode() bool {
+	return !c.testMode
+}
+
+// Ping makes a lightweight authenticated request to check that the API is reachable and
+// the API key is accepted. Network failures are reported as a BagelPayServerError.
+func (c *BagelPayClient) Ping(ctx context.Context) error {
+	resp, err := c.makeRequest(ctx, "GET", "/api/health", nil, nil)
+	if err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+		return NewBagelPayServerError("BagelPay API is unreachable", http.StatusServiceUnavailable, "", nil, err)
+	}
+
+	return c.handleResponse(resp, nil)
+}
+
+// ValidateAPIKey checks the client's API key and reports what it grants access to.
+// A rejected key is reported as a BagelPayAuthenticationError.
+func (c *BagelPayClient) ValidateAPIKey(ctx context.Context) (*APIKeyInfo, error) {
+	resp, err := c.makeRequest(ctx, "GET", "/api/auth/validate", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data APIKeyInfo `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
 	}
+
+	return &apiResp.Data, nil
 }
 
 // makeRequest makes an HTTP request to the API
@@ -88,43 +226,104 @@ func (c *BagelPayClient) makeRequest(ctx context.Context, method, endpoint strin
 	}
 
 	// Prepare request body
-	var body io.Reader
+	var jsonData []byte
 	if data != nil && (method == "POST" || method == "PUT" || method == "PATCH") {
-		jsonData, err := json.Marshal(data)
+		jsonData, err = json.Marshal(data)
 		if err != nil {
 			return nil, NewBagelPayError("failed to marshal request data", err)
 		}
-		body = bytes.NewBuffer(jsonData)
 	}
 
-	// Create request
-	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	// Use the same idempotency key for every attempt
+	idempotencyKey, err := c.idempotencyKeyFor(method, data)
 	if err != nil {
-		return nil, NewBagelPayError("failed to create request", err)
+		return nil, NewBagelPayError("failed to generate idempotency key", err)
 	}
 
-	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "BagelPay-Go-SDK/1.0.0")
-	req.Header.Set("x-api-key", c.apiKey)
+	for attempt := 0; ; attempt++ {
+		var body io.Reader
+		if jsonData != nil {
+			body = bytes.NewReader(jsonData)
+		}
 
-	// Make request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, NewBagelPayError("request failed", err)
-	}
+		// Create request, bounded by the effective timeout
+		reqCtx, cancel := c.requestContext(ctx)
+		reqCtx = context.WithValue(reqCtx, retryAttemptKey{}, attempt)
+		req, err := http.NewRequestWithContext(reqCtx, method, u.String(), body)
+		if err != nil {
+			cancel()
+			return nil, NewBagelPayError("failed to create request", err)
+		}
+
+		// Set headers
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", "BagelPay-Go-SDK/1.0.0")
+		req.Header.Set("x-api-key", c.apiKey)
+		if idempotencyKey != "" {
+			req.Header.Set(IdempotencyKeyHeader, idempotencyKey)
+		}
+		c.cache.prepare(req)
+
+		// Make request
+		var endSpan func(*http.Response, error)
+		if c.tracer != nil {
+			req, endSpan = c.tracer.start(req, endpoint)
+		}
+		c.logRequest(ctx, req, requestIDOf(data))
+		start := time.Now()
+		resp, err := c.httpClient.Do(req)
+		c.logResponse(ctx, req, resp, err, time.Since(start))
+		if endSpan != nil {
+			endSpan(resp, err)
+		}
+		if err != nil {
+			cancel()
+			// Middlewares such as circuit breakers fail requests with a server error of their own
+			var serverErr *BagelPayServerError
+			if errors.As(err, &serverErr) {
+				return nil, serverErr
+			}
+			return nil, NewBagelPayError("request failed", err)
+		}
+
+		// Retry rate-limited requests with exponential backoff
+		var wait time.Duration
+		retry := resp.StatusCode == http.StatusTooManyRequests && attempt < c.retry.MaxRetries
+		if retry {
+			wait, retry = c.retry.delay(attempt, resp.Header)
+		}
+		if !retry {
+			// Keep the request context alive until the body has been read
+			resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+			if err := c.cache.resolve(req, resp); err != nil {
+				resp.Body.Close()
+				return nil, err
+			}
+			return resp, nil
+		}
+		c.logRetry(ctx, req, attempt+1, wait)
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		cancel()
 
-	return resp, nil
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
 }
 
 // handleResponse processes the HTTP response and handles errors
 func (c *BagelPayClient) handleResponse(resp *http.Response, result interface{}) error {
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	// Read one byte past the limit to tell a body of exactly the limit from a larger one
+	body, err := io.ReadAll(io.LimitReader(resp.Body, c.maxBodyBytes+1))
 	if err != nil {
 		return NewBagelPayError("failed to read response body", err)
 	}
+	if int64(len(body)) > c.maxBodyBytes {
+		return NewBagelPayError(fmt.Sprintf("response body exceeds the %d byte limit (HTTP %d)", c.maxBodyBytes, resp.StatusCode), nil)
+	}
 
 	// Check for API errors
 	if resp.StatusCode >= 400 {
@@ -138,21 +337,35 @@ func (c *BagelPayClient) handleResponse(resp *http.Response, result interface{})
 		}
 
 		// Return specific error types based on status code
+		var apiErr interface {
+			error
+			base() *BagelPayAPIError
+		}
 		switch resp.StatusCode {
 		case http.StatusUnauthorized:
-			return NewBagelPayAuthenticationErrorSimple(apiError.Message, nil)
-		case http.StatusBadRequest:
-			return NewBagelPayValidationErrorSimple(apiError.Message, nil)
+			apiErr = NewBagelPayAuthenticationErrorSimple(apiError.Message, nil)
+		case http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity:
+			apiErr = NewBagelPayValidationErrorSimple(apiError.Message, nil)
 		case http.StatusNotFound:
-			return NewBagelPayNotFoundErrorSimple(apiError.Message, nil)
+			apiErr = NewBagelPayNotFoundErrorSimple(apiError.Message, nil)
 		case http.StatusTooManyRequests:
-			return NewBagelPayRateLimitErrorSimple(apiError.Message, nil)
+			retryAfter, ok := parseRetryAfter(resp.Header)
+			if !ok {
+				retryAfter, _ = c.retry.delay(c.retry.MaxRetries, resp.Header)
+			}
+			apiErr = NewBagelPayRateLimitError(apiError.Message, resp.StatusCode, "", nil, retryAfter, nil)
 		default:
 			if resp.StatusCode >= 500 {
-				return NewBagelPayServerErrorSimple(resp.StatusCode, apiError.Message, nil)
+				apiErr = NewBagelPayServerErrorSimple(resp.StatusCode, apiError.Message, nil)
+			} else {
+				apiErr = NewBagelPayAPIError(resp.StatusCode, &apiError, nil)
 			}
-			return NewBagelPayAPIError(resp.StatusCode, &apiError, nil)
 		}
+
+		// Attach the IDs support needs to find the request in server logs
+		apiErr.base().RequestID = resp.Header.Get(RequestIDHeader)
+		apiErr.base().TraceID = resp.Header.Get(TraceIDHeader)
+		return apiErr
 	}
 
 	// Parse successful response
@@ -165,8 +378,26 @@ func (c *BagelPayClient) handleResponse(resp *http.Response, result interface{})
 	return nil
 }
 
-// CreateCheckout creates a new checkout session
+// CreateCheckout creates a new checkout session. With a PaymentMethodToken the saved
+// payment method is charged immediately; an expired or invalid token is reported as a
+// BagelPayValidationError. A nil Locale is sent as DefaultCheckoutLocale.
 func (c *BagelPayClient) CreateCheckout(ctx context.Context, request CheckoutRequest) (*CheckoutResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	if request.Locale == nil {
+		request.Locale = StringPtr(DefaultCheckoutLocale)
+	}
+
+	// Generate a request ID so the checkout can be retried idempotently
+	if request.RequestID == nil && c.autoRequestID {
+		requestID, err := newUUID()
+		if err != nil {
+			return nil, NewBagelPayError("failed to generate request ID", err)
+		}
+		request.RequestID = &requestID
+	}
+
 	resp, err := c.makeRequest(ctx, "POST", "/api/payments/checkouts", request, nil)
 	if err != nil {
 		return nil, err
@@ -178,19 +409,26 @@ func (c *BagelPayClient) CreateCheckout(ctx context.Context, request CheckoutReq
 	if err := c.handleResponse(resp, &apiResp); err != nil {
 		return nil, err
 	}
+	if apiResp.Data.RequestID == nil {
+		apiResp.Data.RequestID = request.RequestID
+	}
 
 	return &apiResp.Data, nil
 }
 
-// CreateProduct creates a new product
-func (c *BagelPayClient) CreateProduct(ctx context.Context, request CreateProductRequest) (*Product, error) {
-	resp, err := c.makeRequest(ctx, "POST", "/api/products/create", request, nil)
+// CreateCartCheckout creates a new checkout session for multiple products
+func (c *BagelPayClient) CreateCartCheckout(ctx context.Context, request CartCheckoutRequest) (*CheckoutResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.makeRequest(ctx, "POST", "/api/payments/checkouts/cart", request, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var apiResp struct {
-		Data Product `json:"data"`
+		Data CheckoutResponse `json:"data"`
 	}
 	if err := c.handleResponse(resp, &apiResp); err != nil {
 		return nil, err
@@ -199,16 +437,35 @@ func (c *BagelPayClient) CreateProduct(ctx context.Context, request CreateProduc
 	return &apiResp.Data, nil
 }
 
-// GetProduct retrieves a product by ID
-func (c *BagelPayClient) GetProduct(ctx context.Context, productID string) (*Product, error) {
-	endpoint := fmt.Sprintf("/api/products/%s", productID)
+// GetCheckout retrieves a checkout session by payment ID
+func (c *BagelPayClient) GetCheckout(ctx context.Context, paymentID string) (*CheckoutResponse, error) {
+	endpoint := fmt.Sprintf("/api/payments/checkouts/%s", paymentID)
 	resp, err := c.makeRequest(ctx, "GET", endpoint, nil, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var apiResp struct {
-		Data Product `json:"data"`
+		Data CheckoutResponse `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// CancelCheckout cancels a pending checkout session by payment ID.
+// Cancelling a session that is already completed or cancelled returns a BagelPayValidationError.
+func (c *BagelPayClient) CancelCheckout(ctx context.Context, paymentID string) (*CheckoutResponse, error) {
+	endpoint := fmt.Sprintf("/api/payments/checkouts/%s/cancel", paymentID)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data CheckoutResponse `json:"data"`
 	}
 	if err := c.handleResponse(resp, &apiResp); err != nil {
 		return nil, err
@@ -217,8 +474,8 @@ func (c *BagelPayClient) GetProduct(ctx context.Context, productID string) (*Pro
 	return &apiResp.Data, nil
 }
 
-// ListProducts retrieves a list of products
-func (c *BagelPayClient) ListProducts(ctx context.Context, pageNum, pageSize int) (*ProductListResponse, error) {
+// ListCheckouts retrieves a list of checkout sessions matching the filter
+func (c *BagelPayClient) ListCheckouts(ctx context.Context, filter CheckoutFilter, pageNum, pageSize int) (*CheckoutListResponse, error) {
 	params := make(map[string]string)
 	if pageSize > 0 {
 		params["pageSize"] = strconv.Itoa(pageSize)
@@ -226,13 +483,14 @@ func (c *BagelPayClient) ListProducts(ctx context.Context, pageNum, pageSize int
 	if pageNum > 0 {
 		params["pageNum"] = strconv.Itoa(pageNum)
 	}
+	filter.apply(params)
 
-	resp, err := c.makeRequest(ctx, "GET", "/api/products/list", nil, params)
+	resp, err := c.makeRequest(ctx, "GET", "/api/payments/checkouts/list", nil, params)
 	if err != nil {
 		return nil, err
 	}
 
-	var result ProductListResponse
+	var result CheckoutListResponse
 	if err := c.handleResponse(resp, &result); err != nil {
 		return nil, err
 	}
@@ -240,33 +498,37 @@ func (c *BagelPayClient) ListProducts(ctx context.Context, pageNum, pageSize int
 	return &result, nil
 }
 
-// UpdateProduct updates an existing product
-func (c *BagelPayClient) UpdateProduct(ctx context.Context, request UpdateProductRequest) (*Product, error) {
-	resp, err := c.makeRequest(ctx, "POST", "/api/products/update", request, nil)
+// ListSupportedLocales retrieves the BCP 47 language tags hosted checkout pages can be
+// rendered in. CreateCheckout requests DefaultCheckoutLocale when Locale is nil.
+func (c *BagelPayClient) ListSupportedLocales(ctx context.Context) ([]string, error) {
+	resp, err := c.makeRequest(ctx, "GET", "/api/locales", nil, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var apiResp struct {
-		Data Product `json:"data"`
+		Data []string `json:"data"`
 	}
 	if err := c.handleResponse(resp, &apiResp); err != nil {
 		return nil, err
 	}
 
-	return &apiResp.Data, nil
+	return apiResp.Data, nil
 }
 
-// ArchiveProduct archives a product by ID
-func (c *BagelPayClient) ArchiveProduct(ctx context.Context, productID string) (*Product, error) {
-	endpoint := fmt.Sprintf("/api/products/%s/archive", productID)
-	resp, err := c.makeRequest(ctx, "POST", endpoint, nil, nil)
+// CreateCoupon creates a new coupon
+func (c *BagelPayClient) CreateCoupon(ctx context.Context, request CouponRequest) (*Coupon, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.makeRequest(ctx, "POST", "/api/coupons/create", request, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var apiResp struct {
-		Data Product `json:"data"`
+		Data Coupon `json:"data"`
 	}
 	if err := c.handleResponse(resp, &apiResp); err != nil {
 		return nil, err
@@ -275,16 +537,16 @@ func (c *BagelPayClient) ArchiveProduct(ctx context.Context, productID string) (
 	return &apiResp.Data, nil
 }
 
-// UnarchiveProduct unarchives a product by ID
-func (c *BagelPayClient) UnarchiveProduct(ctx context.Context, productID string) (*Product, error) {
-	endpoint := fmt.Sprintf("/api/products/%s/unarchive", productID)
-	resp, err := c.makeRequest(ctx, "POST", endpoint, nil, nil)
+// GetCoupon retrieves a coupon by code
+func (c *BagelPayClient) GetCoupon(ctx context.Context, code string) (*Coupon, error) {
+	endpoint := fmt.Sprintf("/api/coupons/%s", url.PathEscape(code))
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var apiResp struct {
-		Data Product `json:"data"`
+		Data Coupon `json:"data"`
 	}
 	if err := c.handleResponse(resp, &apiResp); err != nil {
 		return nil, err
@@ -293,8 +555,8 @@ func (c *BagelPayClient) UnarchiveProduct(ctx context.Context, productID string)
 	return &apiResp.Data, nil
 }
 
-// ListTransactions retrieves a list of transactions
-func (c *BagelPayClient) ListTransactions(ctx context.Context, pageNum, pageSize int) (*TransactionListResponse, error) {
+// ListCoupons retrieves a list of coupons
+func (c *BagelPayClient) ListCoupons(ctx context.Context, pageNum, pageSize int) (*CouponListResponse, error) {
 	params := make(map[string]string)
 	if pageSize > 0 {
 		params["pageSize"] = strconv.Itoa(pageSize)
@@ -303,12 +565,12 @@ func (c *BagelPayClient) ListTransactions(ctx context.Context, pageNum, pageSize
 		params["pageNum"] = strconv.Itoa(pageNum)
 	}
 
-	resp, err := c.makeRequest(ctx, "GET", "/api/transactions/list", nil, params)
+	resp, err := c.makeRequest(ctx, "GET", "/api/coupons/list", nil, params)
 	if err != nil {
 		return nil, err
 	}
 
-	var result TransactionListResponse
+	var result CouponListResponse
 	if err := c.handleResponse(resp, &result); err != nil {
 		return nil, err
 	}
@@ -316,8 +578,63 @@ func (c *BagelPayClient) ListTransactions(ctx context.Context, pageNum, pageSize
 	return &result, nil
 }
 
-// ListSubscriptions retrieves a list of subscriptions
-func (c *BagelPayClient) ListSubscriptions(ctx context.Context, pageNum, pageSize int) (*SubscriptionListResponse, error) {
+// ExpireCoupon expires a coupon immediately so it can no longer be redeemed
+func (c *BagelPayClient) ExpireCoupon(ctx context.Context, code string) error {
+	endpoint := fmt.Sprintf("/api/coupons/%s/expire", url.PathEscape(code))
+	resp, err := c.makeRequest(ctx, "POST", endpoint, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.handleResponse(resp, nil)
+}
+
+// GeneratePaymentLink creates a reusable payment link for a product. Unlike a checkout
+// session, the link can be shared and visited any number of times, up to opts.MaxUses.
+func (c *BagelPayClient) GeneratePaymentLink(ctx context.Context, productID string, opts PaymentLinkOptions) (*PaymentLink, error) {
+	if strings.TrimSpace(productID) == "" {
+		return nil, newValidationError("product_id is required")
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	request := paymentLinkRequest{ProductID: productID, PaymentLinkOptions: opts}
+	resp, err := c.makeRequest(ctx, "POST", "/api/payment-links/create", request, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data PaymentLink `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// GetPaymentLink retrieves a payment link by ID
+func (c *BagelPayClient) GetPaymentLink(ctx context.Context, linkID string) (*PaymentLink, error) {
+	endpoint := fmt.Sprintf("/api/payment-links/%s", linkID)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data PaymentLink `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// ListPaymentLinks retrieves a list of payment links
+func (c *BagelPayClient) ListPaymentLinks(ctx context.Context, pageNum, pageSize int) (*PaymentLinkListResponse, error) {
 	params := make(map[string]string)
 	if pageSize > 0 {
 		params["pageSize"] = strconv.Itoa(pageSize)
@@ -326,12 +643,12 @@ func (c *BagelPayClient) ListSubscriptions(ctx context.Context, pageNum, pageSiz
 		params["pageNum"] = strconv.Itoa(pageNum)
 	}
 
-	resp, err := c.makeRequest(ctx, "GET", "/api/subscriptions/list", nil, params)
+	resp, err := c.makeRequest(ctx, "GET", "/api/payment-links/list", nil, params)
 	if err != nil {
 		return nil, err
 	}
 
-	var result SubscriptionListResponse
+	var result PaymentLinkListResponse
 	if err := c.handleResponse(resp, &result); err != nil {
 		return nil, err
 	}
@@ -339,16 +656,30 @@ func (c *BagelPayClient) ListSubscriptions(ctx context.Context, pageNum, pageSiz
 	return &result, nil
 }
 
-// GetSubscription retrieves a subscription by ID
-func (c *BagelPayClient) GetSubscription(ctx context.Context, subscriptionID string) (*Subscription, error) {
-	endpoint := fmt.Sprintf("/api/subscriptions/%s", subscriptionID)
-	resp, err := c.makeRequest(ctx, "GET", endpoint, nil, nil)
+// DeletePaymentLink deactivates a payment link so it no longer starts checkouts
+func (c *BagelPayClient) DeletePaymentLink(ctx context.Context, linkID string) error {
+	endpoint := fmt.Sprintf("/api/payment-links/%s/delete", linkID)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.handleResponse(resp, nil)
+}
+
+// CreateProduct creates a new product
+func (c *BagelPayClient) CreateProduct(ctx context.Context, request CreateProductRequest) (*Product, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.makeRequest(ctx, "POST", "/api/products/create", request, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var apiResp struct {
-		Data Subscription `json:"data"`
+		Data Product `json:"data"`
 	}
 	if err := c.handleResponse(resp, &apiResp); err != nil {
 		return nil, err
@@ -357,16 +688,16 @@ func (c *BagelPayClient) GetSubscription(ctx context.Context, subscriptionID str
 	return &apiResp.Data, nil
 }
 
-// CancelSubscription cancels a subscription by ID
-func (c *BagelPayClient) CancelSubscription(ctx context.Context, subscriptionID string) (*Subscription, error) {
-	endpoint := fmt.Sprintf("/api/subscriptions/%s/cancel", subscriptionID)
-	resp, err := c.makeRequest(ctx, "POST", endpoint, nil, nil)
+// GetProduct retrieves a product by ID
+func (c *BagelPayClient) GetProduct(ctx context.Context, productID string) (*Product, error) {
+	endpoint := fmt.Sprintf("/api/products/%s", productID)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var apiResp struct {
-		Data Subscription `json:"data"`
+		Data Product `json:"data"`
 	}
 	if err := c.handleResponse(resp, &apiResp); err != nil {
 		return nil, err
@@ -375,8 +706,12 @@ func (c *BagelPayClient) CancelSubscription(ctx context.Context, subscriptionID
 	return &apiResp.Data, nil
 }
 
-// ListCustomers retrieves a list of customers
-func (c *BagelPayClient) ListCustomers(ctx context.Context, pageNum, pageSize int) (*CustomerListResponse, error) {
+// ListProducts retrieves a list of products matching the filter
+func (c *BagelPayClient) ListProducts(ctx context.Context, filter ProductFilter, pageNum, pageSize int) (*ProductListResponse, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
 	params := make(map[string]string)
 	if pageSize > 0 {
 		params["pageSize"] = strconv.Itoa(pageSize)
@@ -384,16 +719,1107 @@ func (c *BagelPayClient) ListCustomers(ctx context.Context, pageNum, pageSize in
 	if pageNum > 0 {
 		params["pageNum"] = strconv.Itoa(pageNum)
 	}
+	filter.apply(params)
 
-	resp, err := c.makeRequest(ctx, "GET", "/api/customers/list", nil, params)
+	resp, err := c.makeRequest(ctx, "GET", "/api/products/list", nil, params)
 	if err != nil {
 		return nil, err
 	}
 
-	var result CustomerListResponse
+	var result ProductListResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListProductCategories retrieves the distinct categories used across the product catalogue
+func (c *BagelPayClient) ListProductCategories(ctx context.Context) ([]string, error) {
+	resp, err := c.makeRequest(ctx, "GET", "/api/products/categories", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data []string `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return apiResp.Data, nil
+}
+
+// SearchProducts finds products whose name or description matches the query
+func (c *BagelPayClient) SearchProducts(ctx context.Context, query string, pageNum, pageSize int) (*ProductListResponse, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, newValidationError("search query is required")
+	}
+
+	params := map[string]string{"q": query}
+	if pageSize > 0 {
+		params["pageSize"] = strconv.Itoa(pageSize)
+	}
+	if pageNum > 0 {
+		params["pageNum"] = strconv.Itoa(pageNum)
+	}
+
+	resp, err := c.makeRequest(ctx, "GET", "/api/products/search", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ProductListResponse
 	if err := c.handleResponse(resp, &result); err != nil {
 		return nil, err
 	}
 
 	return &result, nil
 }
+
+// UpdateProduct updates an existing product
+func (c *BagelPayClient) UpdateProduct(ctx context.Context, request UpdateProductRequest) (*Product, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.makeRequest(ctx, "POST", "/api/products/update", request, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data Product `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// PatchProduct updates only the fields set in the request, leaving the rest unchanged
+func (c *BagelPayClient) PatchProduct(ctx context.Context, request PatchProductRequest) (*Product, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.makeRequest(ctx, "POST", "/api/products/update", request, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data Product `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// DuplicateProduct creates a copy of a product. Non-nil fields in overrides replace the
+// corresponding fields of the source product; pass nil to copy it unchanged.
+func (c *BagelPayClient) DuplicateProduct(ctx context.Context, productID string, overrides *ProductOverrides) (*Product, error) {
+	var data interface{}
+	if overrides != nil {
+		if err := overrides.Validate(); err != nil {
+			return nil, err
+		}
+		data = *overrides
+	}
+
+	endpoint := fmt.Sprintf("/api/products/%s/duplicate", productID)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, data, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data Product `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// ArchiveProduct archives a product by ID
+func (c *BagelPayClient) ArchiveProduct(ctx context.Context, productID string) (*Product, error) {
+	endpoint := fmt.Sprintf("/api/products/%s/archive", productID)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data Product `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// UnarchiveProduct unarchives a product by ID
+func (c *BagelPayClient) UnarchiveProduct(ctx context.Context, productID string) (*Product, error) {
+	endpoint := fmt.Sprintf("/api/products/%s/unarchive", productID)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data Product `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// BulkArchiveProducts archives several products in one request. Products that could
+// not be archived are reported in the result's Failed list rather than as an error.
+func (c *BagelPayClient) BulkArchiveProducts(ctx context.Context, productIDs []string) (*BulkOperationResult, error) {
+	return c.bulkProductAction(ctx, "/api/products/bulk-archive", productIDs)
+}
+
+// BulkUnarchiveProducts unarchives several products in one request. Products that could
+// not be unarchived are reported in the result's Failed list rather than as an error.
+func (c *BagelPayClient) BulkUnarchiveProducts(ctx context.Context, productIDs []string) (*BulkOperationResult, error) {
+	return c.bulkProductAction(ctx, "/api/products/bulk-unarchive", productIDs)
+}
+
+// bulkProductAction posts the product IDs to a bulk product endpoint
+func (c *BagelPayClient) bulkProductAction(ctx context.Context, endpoint string, productIDs []string) (*BulkOperationResult, error) {
+	if len(productIDs) == 0 {
+		return nil, newValidationError("at least one product ID is required")
+	}
+
+	data := map[string][]string{"product_ids": productIDs}
+	resp, err := c.makeRequest(ctx, "POST", endpoint, data, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data BulkOperationResult `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// ListTransactions retrieves a list of transactions matching the filter
+func (c *BagelPayClient) ListTransactions(ctx context.Context, filter TransactionFilter, pageNum, pageSize int) (*TransactionListResponse, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	params := make(map[string]string)
+	if pageSize > 0 {
+		params["pageSize"] = strconv.Itoa(pageSize)
+	}
+	if pageNum > 0 {
+		params["pageNum"] = strconv.Itoa(pageNum)
+	}
+	filter.apply(params)
+
+	resp, err := c.makeRequest(ctx, "GET", "/api/transactions/list", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result TransactionListResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListProductTransactions retrieves the transactions for a product
+func (c *BagelPayClient) ListProductTransactions(ctx context.Context, productID string, pageNum, pageSize int) (*TransactionListResponse, error) {
+	return c.ListTransactions(ctx, TransactionFilter{ProductID: &productID}, pageNum, pageSize)
+}
+
+// ListSubscriptionTransactions retrieves the transactions for a subscription
+func (c *BagelPayClient) ListSubscriptionTransactions(ctx context.Context, subscriptionID string, pageNum, pageSize int) (*TransactionListResponse, error) {
+	return c.ListTransactions(ctx, TransactionFilter{SubscriptionID: &subscriptionID}, pageNum, pageSize)
+}
+
+// GetTransaction retrieves a transaction by ID
+func (c *BagelPayClient) GetTransaction(ctx context.Context, transactionID string) (*Transaction, error) {
+	endpoint := fmt.Sprintf("/api/transactions/%s", transactionID)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data Transaction `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// CreateRefund refunds a transaction in full or in part.
+// Leave Amount at zero to refund the full refundable balance.
+func (c *BagelPayClient) CreateRefund(ctx context.Context, request RefundRequest) (*Refund, error) {
+	resp, err := c.makeRequest(ctx, "POST", "/api/refunds/create", request, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data Refund `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// ListRefunds retrieves a list of refunds, optionally limited to a single transaction
+func (c *BagelPayClient) ListRefunds(ctx context.Context, transactionID string, pageNum, pageSize int) (*RefundListResponse, error) {
+	params := make(map[string]string)
+	if pageSize > 0 {
+		params["pageSize"] = strconv.Itoa(pageSize)
+	}
+	if pageNum > 0 {
+		params["pageNum"] = strconv.Itoa(pageNum)
+	}
+	params["transactionId"] = transactionID
+
+	resp, err := c.makeRequest(ctx, "GET", "/api/refunds/list", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result RefundListResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListDisputes retrieves a list of disputes matching the filter
+func (c *BagelPayClient) ListDisputes(ctx context.Context, filter DisputeFilter, pageNum, pageSize int) (*DisputeListResponse, error) {
+	params := make(map[string]string)
+	if pageSize > 0 {
+		params["pageSize"] = strconv.Itoa(pageSize)
+	}
+	if pageNum > 0 {
+		params["pageNum"] = strconv.Itoa(pageNum)
+	}
+	filter.apply(params)
+
+	resp, err := c.makeRequest(ctx, "GET", "/api/disputes/list", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result DisputeListResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetDispute retrieves a dispute by ID
+func (c *BagelPayClient) GetDispute(ctx context.Context, disputeID string) (*Dispute, error) {
+	endpoint := fmt.Sprintf("/api/disputes/%s", disputeID)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data Dispute `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// SubmitDisputeEvidence submits evidence to contest a dispute. Evidence must arrive
+// before the dispute's DueBy time; the API rejects late or decided disputes with a
+// BagelPayValidationError.
+func (c *BagelPayClient) SubmitDisputeEvidence(ctx context.Context, disputeID string, evidence DisputeEvidence) (*Dispute, error) {
+	if err := evidence.Validate(); err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("/api/disputes/%s/evidence", disputeID)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, evidence, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data Dispute `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// ListInvoices retrieves a list of invoices matching the filter
+func (c *BagelPayClient) ListInvoices(ctx context.Context, filter InvoiceFilter, pageNum, pageSize int) (*InvoiceListResponse, error) {
+	params := make(map[string]string)
+	if pageSize > 0 {
+		params["pageSize"] = strconv.Itoa(pageSize)
+	}
+	if pageNum > 0 {
+		params["pageNum"] = strconv.Itoa(pageNum)
+	}
+	filter.apply(params)
+
+	resp, err := c.makeRequest(ctx, "GET", "/api/invoices/list", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result InvoiceListResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListSubscriptionInvoices retrieves the billing history of a subscription
+func (c *BagelPayClient) ListSubscriptionInvoices(ctx context.Context, subscriptionID string, pageNum, pageSize int) (*InvoiceListResponse, error) {
+	return c.ListInvoices(ctx, InvoiceFilter{SubscriptionID: &subscriptionID}, pageNum, pageSize)
+}
+
+// GetInvoice retrieves an invoice by ID
+func (c *BagelPayClient) GetInvoice(ctx context.Context, invoiceID string) (*Invoice, error) {
+	endpoint := fmt.Sprintf("/api/invoices/%s", invoiceID)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data Invoice `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// ListSubscriptions retrieves a list of subscriptions matching the filter
+func (c *BagelPayClient) ListSubscriptions(ctx context.Context, filter SubscriptionFilter, pageNum, pageSize int) (*SubscriptionListResponse, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	params := make(map[string]string)
+	if pageSize > 0 {
+		params["pageSize"] = strconv.Itoa(pageSize)
+	}
+	if pageNum > 0 {
+		params["pageNum"] = strconv.Itoa(pageNum)
+	}
+	filter.apply(params)
+
+	resp, err := c.makeRequest(ctx, "GET", "/api/subscriptions/list", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result SubscriptionListResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetCustomerSubscriptions retrieves the subscriptions belonging to a customer email
+func (c *BagelPayClient) GetCustomerSubscriptions(ctx context.Context, customerEmail string, pageNum, pageSize int) (*SubscriptionListResponse, error) {
+	if strings.TrimSpace(customerEmail) == "" {
+		return nil, newValidationError("customer email is required")
+	}
+	return c.ListSubscriptions(ctx, SubscriptionFilter{CustomerEmail: &customerEmail}, pageNum, pageSize)
+}
+
+// GetSubscription retrieves a subscription by ID
+func (c *BagelPayClient) GetSubscription(ctx context.Context, subscriptionID string) (*Subscription, error) {
+	endpoint := fmt.Sprintf("/api/subscriptions/%s", subscriptionID)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data Subscription `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// CancelSubscription cancels a subscription by ID, either immediately or at the end of
+// the current billing period
+func (c *BagelPayClient) CancelSubscription(ctx context.Context, subscriptionID string, opts CancelSubscriptionOptions) (*Subscription, error) {
+	endpoint := fmt.Sprintf("/api/subscriptions/%s/cancel", subscriptionID)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, opts, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data Subscription `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// BulkCancelSubscriptions cancels several subscriptions in one request with the same
+// options. Subscriptions that could not be cancelled are reported in the result's
+// Failed list rather than as an error.
+func (c *BagelPayClient) BulkCancelSubscriptions(ctx context.Context, subscriptionIDs []string, opts CancelSubscriptionOptions) (*BulkOperationResult, error) {
+	if len(subscriptionIDs) == 0 {
+		return nil, newValidationError("at least one subscription ID is required")
+	}
+
+	request := bulkCancelRequest{SubscriptionIDs: subscriptionIDs, CancelSubscriptionOptions: opts}
+	resp, err := c.makeRequest(ctx, "POST", "/api/subscriptions/bulk-cancel", request, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data BulkOperationResult `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// ReactivateSubscription undoes an end-of-period cancellation before the subscription
+// terminates. The API rejects terminated subscriptions with a BagelPayValidationError.
+func (c *BagelPayClient) ReactivateSubscription(ctx context.Context, subscriptionID string) (*Subscription, error) {
+	endpoint := fmt.Sprintf("/api/subscriptions/%s/reactivate", subscriptionID)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data Subscription `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// PauseSubscription pauses a subscription by ID
+func (c *BagelPayClient) PauseSubscription(ctx context.Context, subscriptionID string, request PauseRequest) (*Subscription, error) {
+	endpoint := fmt.Sprintf("/api/subscriptions/%s/pause", subscriptionID)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, request, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data Subscription `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// ResumeSubscription resumes a paused subscription by ID
+func (c *BagelPayClient) ResumeSubscription(ctx context.Context, subscriptionID string) (*Subscription, error) {
+	endpoint := fmt.Sprintf("/api/subscriptions/%s/resume", subscriptionID)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data Subscription `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// ChangeSubscriptionPlan upgrades or downgrades a subscription to another product
+func (c *BagelPayClient) ChangeSubscriptionPlan(ctx context.Context, subscriptionID string, request ChangePlanRequest) (*Subscription, error) {
+	endpoint := fmt.Sprintf("/api/subscriptions/%s/change-plan", subscriptionID)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, request, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data Subscription `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// ExtendTrial adds days to the trial of a trialing subscription. The API rejects
+// subscriptions that are not in trial with a BagelPayValidationError.
+func (c *BagelPayClient) ExtendTrial(ctx context.Context, subscriptionID string, additionalDays int) (*Subscription, error) {
+	if additionalDays < 1 {
+		return nil, newValidationError(fmt.Sprintf("additional_days must be positive, got %d", additionalDays))
+	}
+
+	endpoint := fmt.Sprintf("/api/subscriptions/%s/extend-trial", subscriptionID)
+	request := map[string]int{"additional_days": additionalDays}
+	resp, err := c.makeRequest(ctx, "POST", endpoint, request, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data Subscription `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// PreviewChangePlan previews the proration of a subscription plan change without applying it
+func (c *BagelPayClient) PreviewChangePlan(ctx context.Context, subscriptionID string, request ChangePlanRequest) (*ProrationPreview, error) {
+	endpoint := fmt.Sprintf("/api/subscriptions/%s/change-plan/preview", subscriptionID)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, request, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data ProrationPreview `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// ListCustomers retrieves a list of customers matching the filter
+func (c *BagelPayClient) ListCustomers(ctx context.Context, filter CustomerFilter, pageNum, pageSize int) (*CustomerListResponse, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	params := make(map[string]string)
+	if pageSize > 0 {
+		params["pageSize"] = strconv.Itoa(pageSize)
+	}
+	if pageNum > 0 {
+		params["pageNum"] = strconv.Itoa(pageNum)
+	}
+	filter.apply(params)
+
+	resp, err := c.makeRequest(ctx, "GET", "/api/customers/list", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result CustomerListResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// SearchCustomers finds customers whose name or email matches the query
+func (c *BagelPayClient) SearchCustomers(ctx context.Context, query string, pageNum, pageSize int) (*CustomerListResponse, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, newValidationError("search query is required")
+	}
+
+	params := map[string]string{"q": query}
+	if pageSize > 0 {
+		params["pageSize"] = strconv.Itoa(pageSize)
+	}
+	if pageNum > 0 {
+		params["pageNum"] = strconv.Itoa(pageNum)
+	}
+
+	resp, err := c.makeRequest(ctx, "GET", "/api/customers/search", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result CustomerListResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetCustomer retrieves a customer by ID
+func (c *BagelPayClient) GetCustomer(ctx context.Context, customerID int) (*CustomerData, error) {
+	endpoint := fmt.Sprintf("/api/customers/%d", customerID)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data CustomerData `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// CreateCustomer creates a new customer
+func (c *BagelPayClient) CreateCustomer(ctx context.Context, request CreateCustomerRequest) (*CustomerData, error) {
+	resp, err := c.makeRequest(ctx, "POST", "/api/customers/create", request, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data CustomerData `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// UpdateCustomer updates an existing customer
+func (c *BagelPayClient) UpdateCustomer(ctx context.Context, request UpdateCustomerRequest) (*CustomerData, error) {
+	resp, err := c.makeRequest(ctx, "POST", "/api/customers/update", request, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data CustomerData `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// DeleteCustomer deletes a customer by ID
+func (c *BagelPayClient) DeleteCustomer(ctx context.Context, customerID int) error {
+	endpoint := fmt.Sprintf("/api/customers/%d/delete", customerID)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.handleResponse(resp, nil)
+}
+
+// CreateCustomerPortalSession creates a single-use link to the customer self-service portal.
+// The customer is sent back to returnURL when they leave the portal.
+func (c *BagelPayClient) CreateCustomerPortalSession(ctx context.Context, customerID int, returnURL string) (*CustomerPortalSession, error) {
+	if returnURL != "" && !isAbsoluteURL(returnURL) {
+		return nil, newValidationError(fmt.Sprintf("return_url must be an absolute URL, got %q", returnURL))
+	}
+
+	endpoint := fmt.Sprintf("/api/customers/%d/portal", customerID)
+	request := map[string]string{"return_url": returnURL}
+	resp, err := c.makeRequest(ctx, "POST", endpoint, request, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data CustomerPortalSession `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// GetCustomerPortalURL returns a single-use customer portal URL.
+// Use CreateCustomerPortalSession to also learn when the URL expires.
+func (c *BagelPayClient) GetCustomerPortalURL(ctx context.Context, customerID int, returnURL string) (string, error) {
+	session, err := c.CreateCustomerPortalSession(ctx, customerID, returnURL)
+	if err != nil {
+		return "", err
+	}
+	if session.URL == nil {
+		return "", NewBagelPayError("portal response did not include a URL", nil)
+	}
+	return *session.URL, nil
+}
+
+// ListPaymentMethods retrieves the payment methods saved for a customer
+func (c *BagelPayClient) ListPaymentMethods(ctx context.Context, customerID int) ([]PaymentMethod, error) {
+	endpoint := fmt.Sprintf("/api/customers/%d/payment-methods", customerID)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data []PaymentMethod `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return apiResp.Data, nil
+}
+
+// DeletePaymentMethod removes a saved payment method by ID
+func (c *BagelPayClient) DeletePaymentMethod(ctx context.Context, paymentMethodID string) error {
+	endpoint := fmt.Sprintf("/api/payment-methods/%s/delete", paymentMethodID)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.handleResponse(resp, nil)
+}
+
+// SetDefaultPaymentMethod makes a saved payment method the customer's default
+func (c *BagelPayClient) SetDefaultPaymentMethod(ctx context.Context, customerID int, paymentMethodID string) error {
+	endpoint := fmt.Sprintf("/api/customers/%d/payment-methods/%s/default", customerID, paymentMethodID)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.handleResponse(resp, nil)
+}
+
+// ListSupportedPaymentMethods retrieves the payment method types checkouts can accept
+func (c *BagelPayClient) ListSupportedPaymentMethods(ctx context.Context) ([]SupportedPaymentMethod, error) {
+	resp, err := c.makeRequest(ctx, "GET", "/api/payment-methods/supported", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data []SupportedPaymentMethod `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return apiResp.Data, nil
+}
+
+// ListSupportedCurrencies retrieves the currencies products and checkouts can be priced in
+func (c *BagelPayClient) ListSupportedCurrencies(ctx context.Context) ([]Currency, error) {
+	resp, err := c.makeRequest(ctx, "GET", "/api/currencies", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data []Currency `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return apiResp.Data, nil
+}
+
+// ListTaxCategories retrieves the tax categories products can be assigned to.
+// A non-nil country limits the result to categories available in that jurisdiction.
+func (c *BagelPayClient) ListTaxCategories(ctx context.Context, country *string) ([]TaxCategory, error) {
+	params := make(map[string]string)
+	if country != nil {
+		params["country"] = *country
+	}
+
+	resp, err := c.makeRequest(ctx, "GET", "/api/tax/categories", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data []TaxCategory `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return apiResp.Data, nil
+}
+
+// GetTaxRate calculates the tax due on a price in a jurisdiction, so prices can be
+// shown with and without tax before the customer reaches checkout
+func (c *BagelPayClient) GetTaxRate(ctx context.Context, request TaxRateRequest) (*TaxRate, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	params := make(map[string]string)
+	request.apply(params)
+
+	resp, err := c.makeRequest(ctx, "GET", "/api/tax/rate", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data TaxRate `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// CreateWebhook registers a new webhook endpoint
+func (c *BagelPayClient) CreateWebhook(ctx context.Context, request WebhookRequest) (*Webhook, error) {
+	resp, err := c.makeRequest(ctx, "POST", "/api/webhooks/create", request, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data Webhook `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// ListWebhooks retrieves all registered webhook endpoints
+func (c *BagelPayClient) ListWebhooks(ctx context.Context) ([]Webhook, error) {
+	resp, err := c.makeRequest(ctx, "GET", "/api/webhooks/list", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data []Webhook `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return apiResp.Data, nil
+}
+
+// DeleteWebhook removes a webhook endpoint by ID
+func (c *BagelPayClient) DeleteWebhook(ctx context.Context, webhookID string) error {
+	endpoint := fmt.Sprintf("/api/webhooks/%s/delete", webhookID)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.handleResponse(resp, nil)
+}
+
+// TriggerTestWebhook sends a test event of the given type to a webhook endpoint
+func (c *BagelPayClient) TriggerTestWebhook(ctx context.Context, webhookID string, eventType string) error {
+	endpoint := fmt.Sprintf("/api/webhooks/%s/test", webhookID)
+	request := map[string]string{"event_type": eventType}
+	resp, err := c.makeRequest(ctx, "POST", endpoint, request, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.handleResponse(resp, nil)
+}
+
+// ListWebhookEvents retrieves the events sent to a webhook endpoint, most recent first
+func (c *BagelPayClient) ListWebhookEvents(ctx context.Context, webhookID string, pageNum, pageSize int) (*WebhookEventListResponse, error) {
+	params := make(map[string]string)
+	if pageSize > 0 {
+		params["pageSize"] = strconv.Itoa(pageSize)
+	}
+	if pageNum > 0 {
+		params["pageNum"] = strconv.Itoa(pageNum)
+	}
+	params["webhookId"] = webhookID
+
+	resp, err := c.makeRequest(ctx, "GET", "/api/webhooks/events/list", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result WebhookEventListResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetWebhookEvent retrieves a webhook event and its delivery status by ID
+func (c *BagelPayClient) GetWebhookEvent(ctx context.Context, eventID string) (*WebhookEvent, error) {
+	endpoint := fmt.Sprintf("/api/webhooks/events/%s", eventID)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data WebhookEvent `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// ResendWebhookEvent queues a webhook event for redelivery to its endpoint
+func (c *BagelPayClient) ResendWebhookEvent(ctx context.Context, eventID string) error {
+	endpoint := fmt.Sprintf("/api/webhooks/events/%s/resend", eventID)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.handleResponse(resp, nil)
+}
+
+// GetRevenueSummary retrieves aggregate revenue figures for a period such as PeriodThisMonth
+func (c *BagelPayClient) GetRevenueSummary(ctx context.Context, period string) (*RevenueSummary, error) {
+	if !containsString(knownRevenuePeriods, period) {
+		return nil, newValidationError(fmt.Sprintf("unrecognised period %q", period))
+	}
+
+	params := map[string]string{"period": period}
+	resp, err := c.makeRequest(ctx, "GET", "/api/analytics/revenue", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data RevenueSummary `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// GetBalance retrieves the merchant's available and pending balances per currency
+func (c *BagelPayClient) GetBalance(ctx context.Context) (*Balance, error) {
+	resp, err := c.makeRequest(ctx, "GET", "/api/balance", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data Balance `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// ListPayouts retrieves a list of payouts to the merchant's bank account, newest first
+func (c *BagelPayClient) ListPayouts(ctx context.Context, pageNum, pageSize int) (*PayoutListResponse, error) {
+	params := make(map[string]string)
+	if pageSize > 0 {
+		params["pageSize"] = strconv.Itoa(pageSize)
+	}
+	if pageNum > 0 {
+		params["pageNum"] = strconv.Itoa(pageNum)
+	}
+
+	resp, err := c.makeRequest(ctx, "GET", "/api/payouts/list", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result PayoutListResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetPayout retrieves a payout by ID
+func (c *BagelPayClient) GetPayout(ctx context.Context, payoutID string) (*Payout, error) {
+	endpoint := fmt.Sprintf("/api/payouts/%s", payoutID)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data Payout `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
+
+// GetStoreInfo retrieves the store the API key belongs to
+func (c *BagelPayClient) GetStoreInfo(ctx context.Context) (*StoreInfo, error) {
+	resp, err := c.makeRequest(ctx, "GET", "/api/store", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data StoreInfo `json:"data"`
+	}
+	if err := c.handleResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return &apiResp.Data, nil
+}
@@ -0,0 +1,546 @@
+package bagelpay
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient starts a server running handler and returns a client pointed at it.
+// configure, if given, adjusts the configuration before the client is created.
+func newTestClient(t *testing.T, handler http.HandlerFunc, configure ...func(*ClientConfig)) *BagelPayClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	config := ClientConfig{
+		APIKey:   "bagel_test_key_1234",
+		BaseURL:  server.URL,
+		TestMode: true,
+		Retry:    &RetryConfig{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond},
+	}
+	for _, fn := range configure {
+		fn(&config)
+	}
+	return NewClient(config)
+}
+
+// rateLimitFor answers the first n requests with 429 and the rest with a store
+func rateLimitFor(n int32, calls *int32, retryAfter string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(calls, 1) <= n {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"message":"slow down"}`))
+			return
+		}
+		w.Write([]byte(`{"data":{"name":"Bagel Shop"}}`))
+	}
+}
+
+func TestRetrySucceedsAfterRateLimits(t *testing.T) {
+	var calls int32
+	client := newTestClient(t, rateLimitFor(2, &calls, ""))
+
+	store, err := client.GetStoreInfo(context.Background())
+	if err != nil {
+		t.Fatalf("GetStoreInfo: %v", err)
+	}
+	if store.Name == nil || *store.Name != "Bagel Shop" {
+		t.Errorf("store name = %v, want Bagel Shop", store.Name)
+	}
+	if calls != 3 {
+		t.Errorf("server calls = %d, want 3", calls)
+	}
+}
+
+func TestRetryExhaustedReturnsRateLimitError(t *testing.T) {
+	var calls int32
+	client := newTestClient(t, rateLimitFor(100, &calls, ""))
+
+	_, err := client.GetStoreInfo(context.Background())
+	var rateLimitErr *BagelPayRateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("error = %v (%T), want *BagelPayRateLimitError", err, err)
+	}
+	if calls != 4 {
+		t.Errorf("server calls = %d, want 4 (1 attempt + 3 retries)", calls)
+	}
+}
+
+func TestRetryHonoursRetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter func() string
+		minWait    time.Duration
+	}{
+		{
+			name:       "seconds",
+			retryAfter: func() string { return "1" },
+			minWait:    900 * time.Millisecond,
+		},
+		{
+			// HTTP-dates have one second resolution, so 2s ahead waits at least 1s
+			name:       "http-date",
+			retryAfter: func() string { return time.Now().Add(2 * time.Second).UTC().Format(http.TimeFormat) },
+			minWait:    900 * time.Millisecond,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			client := newTestClient(t, rateLimitFor(1, &calls, tt.retryAfter()), func(c *ClientConfig) {
+				c.Retry = &RetryConfig{MaxRetries: 1, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Second}
+			})
+
+			start := time.Now()
+			if _, err := client.GetStoreInfo(context.Background()); err != nil {
+				t.Fatalf("GetStoreInfo: %v", err)
+			}
+			if elapsed := time.Since(start); elapsed < tt.minWait {
+				t.Errorf("retried after %s, want at least %s", elapsed, tt.minWait)
+			}
+			if calls != 2 {
+				t.Errorf("server calls = %d, want 2", calls)
+			}
+		})
+	}
+}
+
+func TestRetryAfterBeyondMaxDelayIsNotRetried(t *testing.T) {
+	var calls int32
+	client := newTestClient(t, rateLimitFor(1, &calls, "60"))
+
+	_, err := client.GetStoreInfo(context.Background())
+	var rateLimitErr *BagelPayRateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("error = %v (%T), want *BagelPayRateLimitError", err, err)
+	}
+	if rateLimitErr.RetryAfter != time.Minute {
+		t.Errorf("RetryAfter = %s, want 1m", rateLimitErr.RetryAfter)
+	}
+	if calls != 1 {
+		t.Errorf("server calls = %d, want 1", calls)
+	}
+}
+
+func TestRetryContextCancelledDuringBackoff(t *testing.T) {
+	var calls int32
+	client := newTestClient(t, rateLimitFor(100, &calls, "5"), func(c *ClientConfig) {
+		c.Retry = &RetryConfig{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Second}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := client.GetStoreInfo(ctx)
+	if err != context.Canceled {
+		t.Fatalf("error = %v, want context.Canceled", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("returned after %s, want prompt return on cancellation", elapsed)
+	}
+	if calls != 1 {
+		t.Errorf("server calls = %d, want 1", calls)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, true},
+	}
+	for _, tt := range tests {
+		header := http.Header{}
+		if tt.value != "" {
+			header.Set("Retry-After", tt.value)
+		}
+		got, ok := parseRetryAfter(header)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %s, %v; want %s, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRetryDelayBackoff(t *testing.T) {
+	config := RetryConfig{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for attempt, want := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		got, ok := config.delay(attempt, http.Header{})
+		if !ok || got != want*time.Millisecond {
+			t.Errorf("delay(%d) = %s, %v; want %s", attempt, got, ok, want*time.Millisecond)
+		}
+	}
+
+	header := http.Header{"Retry-After": []string{strconv.Itoa(2)}}
+	if _, ok := config.delay(0, header); ok {
+		t.Error("delay with Retry-After above MaxDelay reported ok")
+	}
+}
+
+func TestDuplicateProductSendsOnlyOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides *ProductOverrides
+		want      string
+	}{
+		{"nil", nil, ""},
+		{"name only", &ProductOverrides{Name: StringPtr("Yearly")}, `{"name":"Yearly"}`},
+		{
+			"name and price",
+			&ProductOverrides{Name: StringPtr("Free"), Price: Float64Ptr(0)},
+			`{"name":"Free","price":0}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path, body string
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				data, _ := io.ReadAll(r.Body)
+				body = string(data)
+				w.Write([]byte(`{"data":{"product_id":"prod_copy"}}`))
+			})
+
+			if _, err := client.DuplicateProduct(context.Background(), "prod_1", tt.overrides); err != nil {
+				t.Fatalf("DuplicateProduct: %v", err)
+			}
+			if path != "/api/products/prod_1/duplicate" {
+				t.Errorf("path = %q", path)
+			}
+			if body != tt.want {
+				t.Errorf("body = %s, want %s", body, tt.want)
+			}
+		})
+	}
+}
+
+func TestEndpointRequests(t *testing.T) {
+	createdAfter := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	refund := TransactionTypeRefund
+
+	tests := []struct {
+		name   string
+		call   func(c *BagelPayClient) error
+		method string
+		path   string
+		query  url.Values
+	}{
+		{
+			name: "GetTransaction",
+			call: func(c *BagelPayClient) error {
+				_, err := c.GetTransaction(context.Background(), "txn_1")
+				return err
+			},
+			method: "GET",
+			path:   "/api/transactions/txn_1",
+		},
+		{
+			name: "GetCustomer",
+			call: func(c *BagelPayClient) error {
+				_, err := c.GetCustomer(context.Background(), 42)
+				return err
+			},
+			method: "GET",
+			path:   "/api/customers/42",
+		},
+		{
+			name: "ListTransactions",
+			call: func(c *BagelPayClient) error {
+				_, err := c.ListTransactions(context.Background(), TransactionFilter{
+					From:      &createdAfter,
+					Type:      &refund,
+					MinAmount: Float64Ptr(9.5),
+					SortBy:    StringPtr(SortByAmount),
+				}, 2, 50)
+				return err
+			},
+			method: "GET",
+			path:   "/api/transactions/list",
+			query: url.Values{
+				"pageNum":   {"2"},
+				"pageSize":  {"50"},
+				"from":      {"2024-01-02T03:04:05Z"},
+				"type":      {"refund"},
+				"minAmount": {"9.5"},
+				"sortBy":    {"amount"},
+			},
+		},
+		{
+			name: "ListProducts",
+			call: func(c *BagelPayClient) error {
+				_, err := c.ListProducts(context.Background(), ProductFilter{
+					IsArchived: BoolPtr(false),
+					Tags:       []string{"a", "b"},
+				}, 1, 10)
+				return err
+			},
+			method: "GET",
+			path:   "/api/products/list",
+			query:  url.Values{"pageNum": {"1"}, "pageSize": {"10"}, "isArchived": {"false"}, "tags": {"a,b"}},
+		},
+		{
+			name: "SearchProducts",
+			call: func(c *BagelPayClient) error {
+				_, err := c.SearchProducts(context.Background(), "bagel & cream", 0, 0)
+				return err
+			},
+			method: "GET",
+			path:   "/api/products/search",
+			query:  url.Values{"q": {"bagel & cream"}},
+		},
+		{
+			name: "ListDisputes",
+			call: func(c *BagelPayClient) error {
+				_, err := c.ListDisputes(context.Background(), DisputeFilter{TransactionID: StringPtr("txn_1")}, 0, 0)
+				return err
+			},
+			method: "GET",
+			path:   "/api/disputes/list",
+			query:  url.Values{"transactionId": {"txn_1"}},
+		},
+		{
+			name: "CancelSubscription",
+			call: func(c *BagelPayClient) error {
+				_, err := c.CancelSubscription(context.Background(), "sub_1", CancelSubscriptionOptions{})
+				return err
+			},
+			method: "POST",
+			path:   "/api/subscriptions/sub_1/cancel",
+		},
+		{
+			name: "DeleteCustomer",
+			call: func(c *BagelPayClient) error {
+				return c.DeleteCustomer(context.Background(), 42)
+			},
+			method: "POST",
+			path:   "/api/customers/42/delete",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				got = r
+				w.Write([]byte(`{"data":{}}`))
+			})
+
+			if err := tt.call(client); err != nil {
+				t.Fatalf("call failed: %v", err)
+			}
+			if got.Method != tt.method || got.URL.Path != tt.path {
+				t.Errorf("request = %s %s, want %s %s", got.Method, got.URL.Path, tt.method, tt.path)
+			}
+			query := got.URL.Query()
+			if len(query) != len(tt.query) {
+				t.Errorf("query = %v, want %v", query, tt.query)
+			}
+			for key, want := range tt.query {
+				if query.Get(key) != want[0] {
+					t.Errorf("query %s = %q, want %q", key, query.Get(key), want[0])
+				}
+			}
+		})
+	}
+}
+
+func TestIdempotencyKeyReusedAcrossRetries(t *testing.T) {
+	var mu sync.Mutex
+	var keys []string
+	var calls int32
+	limited := rateLimitFor(2, &calls, "")
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		mu.Unlock()
+		limited(w, r)
+	})
+
+	if _, err := client.CreateRefund(context.Background(), RefundRequest{TransactionID: "txn_1"}); err != nil {
+		t.Fatalf("CreateRefund: %v", err)
+	}
+	if len(keys) != 3 {
+		t.Fatalf("got %d attempts, want 3", len(keys))
+	}
+	if keys[0] == "" {
+		t.Fatal("no idempotency key was generated")
+	}
+	for i, key := range keys[1:] {
+		if key != keys[0] {
+			t.Errorf("attempt %d sent key %q, want %q", i+2, key, keys[0])
+		}
+	}
+
+	// A second call is a new operation and gets a new key
+	if _, err := client.CreateRefund(context.Background(), RefundRequest{TransactionID: "txn_1"}); err != nil {
+		t.Fatalf("CreateRefund: %v", err)
+	}
+	if last := keys[len(keys)-1]; last == keys[0] {
+		t.Errorf("second call reused key %q", last)
+	}
+}
+
+func TestWithRequestTimeoutExpires(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+
+	start := time.Now()
+	_, err := client.GetStoreInfo(WithRequestTimeout(context.Background(), 50*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("returned after %s, want the 50ms override to apply", elapsed)
+	}
+}
+
+func TestGetCustomerDecodesDetailFields(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{
+			"id": 42,
+			"email": "ada@example.com",
+			"phone": "+44 20 7946 0000",
+			"address": {"line1": "1 Bagel Street", "city": "London", "postal_code": "E1 6AN", "country": "GB"},
+			"lifetime_value_history": [
+				{"period": "2024-01", "amount": 19.99, "currency": "USD"},
+				{"period": "2024-02", "amount": 29.99, "currency": "USD"}
+			]
+		}}`))
+	})
+
+	customer, err := client.GetCustomer(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("GetCustomer: %v", err)
+	}
+	if customer.ID == nil || *customer.ID != 42 {
+		t.Errorf("ID = %v, want 42", customer.ID)
+	}
+	if customer.Phone == nil || *customer.Phone != "+44 20 7946 0000" {
+		t.Errorf("Phone = %v", customer.Phone)
+	}
+	if customer.Address == nil || customer.Address.City != "London" || customer.Address.PostalCode != "E1 6AN" {
+		t.Errorf("Address = %+v", customer.Address)
+	}
+	if len(customer.LifetimeValueHistory) != 2 || *customer.LifetimeValueHistory[1].Amount != 29.99 {
+		t.Errorf("LifetimeValueHistory = %+v", customer.LifetimeValueHistory)
+	}
+}
+
+func TestListSubscriptionsFilterParams(t *testing.T) {
+	after := time.Date(2024, 3, 1, 0, 0, 0, 0, time.FixedZone("CET", 3600))
+	before := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter SubscriptionFilter
+		key    string
+		want   string
+	}{
+		{"status", SubscriptionFilter{Status: StringPtr("active")}, "status", "active"},
+		{"product", SubscriptionFilter{ProductID: StringPtr("prod_1")}, "productId", "prod_1"},
+		{"customer email", SubscriptionFilter{CustomerEmail: StringPtr("ada+1@example.com")}, "customerEmail", "ada+1@example.com"},
+		{"created after", SubscriptionFilter{CreatedAfter: &after}, "createdAfter", "2024-02-29T23:00:00Z"},
+		{"created before", SubscriptionFilter{CreatedBefore: &before}, "createdBefore", "2024-04-01T00:00:00Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var query url.Values
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/subscriptions/list" {
+					t.Errorf("path = %q", r.URL.Path)
+				}
+				query = r.URL.Query()
+				w.Write([]byte(`{"items":[]}`))
+			})
+
+			if _, err := client.ListSubscriptions(context.Background(), tt.filter, 0, 0); err != nil {
+				t.Fatalf("ListSubscriptions: %v", err)
+			}
+			if len(query) != 1 || query.Get(tt.key) != tt.want {
+				t.Errorf("query = %v, want only %s=%s", query, tt.key, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitErrorCarriesRetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter string
+		want       time.Duration
+	}{
+		{"seconds", "120", 2 * time.Minute},
+		// Without the header the client suggests its own backoff delay
+		{"missing", "", DefaultRetryBaseDelay},
+		{"http-date in the past", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			client := newTestClient(t, rateLimitFor(1, &calls, tt.retryAfter), func(c *ClientConfig) {
+				c.Retry = &RetryConfig{MaxRetries: 0}
+			})
+
+			_, err := client.GetStoreInfo(context.Background())
+			var rateLimitErr *BagelPayRateLimitError
+			if !errors.As(err, &rateLimitErr) {
+				t.Fatalf("error = %v (%T), want *BagelPayRateLimitError", err, err)
+			}
+			if rateLimitErr.RetryAfter != tt.want {
+				t.Errorf("RetryAfter = %s, want %s", rateLimitErr.RetryAfter, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateCheckoutDefaultsLocale(t *testing.T) {
+	tests := []struct {
+		name    string
+		request CheckoutRequest
+		want    string
+	}{
+		{"nil locale", NewCheckoutRequestBuilder("prod_1").Build(), `"locale":"en"`},
+		{"explicit locale", NewCheckoutRequestBuilder("prod_1").WithLocale("fr").Build(), `"locale":"fr"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body string
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				data, _ := io.ReadAll(r.Body)
+				body = string(data)
+				w.Write([]byte(`{"data":{"payment_id":"pay_1"}}`))
+			})
+
+			if _, err := client.CreateCheckout(context.Background(), tt.request); err != nil {
+				t.Fatalf("CreateCheckout: %v", err)
+			}
+			if !strings.Contains(body, tt.want) {
+				t.Errorf("body = %s, want it to contain %s", body, tt.want)
+			}
+		})
+	}
+}
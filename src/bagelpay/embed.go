@@ -0,0 +1,57 @@
+package bagelpay
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+)
+
+// Embed defaults used when EmbedOptions leaves a size empty
+const (
+	DefaultEmbedWidth  = "100%"
+	DefaultEmbedHeight = "600px"
+)
+
+// EmbedOptions controls the HTML produced by GenerateEmbedCode
+type EmbedOptions struct {
+	// Width and Height size the inline iframe as CSS lengths such as "100%" or "600px"
+	Width  string
+	Height string
+	// ButtonText renders a button that opens the checkout in a new tab instead of an inline iframe
+	ButtonText string
+	// Theme selects the checkout page theme, such as "light" or "dark"
+	Theme string
+}
+
+// GenerateEmbedCode returns HTML that embeds a checkout in a web page: an iframe
+// showing the checkout inline, or a button linking to it when opts.ButtonText is set.
+// All values are HTML-escaped. It returns an empty string when checkoutURL is not an
+// absolute http or https URL. No network call is made.
+func GenerateEmbedCode(checkoutURL string, opts EmbedOptions) string {
+	u, err := url.Parse(checkoutURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return ""
+	}
+	q := u.Query()
+	if opts.Theme != "" {
+		q.Set("theme", opts.Theme)
+	}
+
+	if opts.ButtonText != "" {
+		u.RawQuery = q.Encode()
+		return fmt.Sprintf(`<a href="%s" class="bagelpay-checkout-button" target="_blank" rel="noopener">%s</a>`,
+			html.EscapeString(u.String()), html.EscapeString(opts.ButtonText))
+	}
+
+	q.Set("embed", "true")
+	u.RawQuery = q.Encode()
+	width, height := opts.Width, opts.Height
+	if width == "" {
+		width = DefaultEmbedWidth
+	}
+	if height == "" {
+		height = DefaultEmbedHeight
+	}
+	return fmt.Sprintf(`<iframe src="%s" width="%s" height="%s" style="border:0" allow="payment" title="BagelPay checkout"></iframe>`,
+		html.EscapeString(u.String()), html.EscapeString(width), html.EscapeString(height))
+}
@@ -0,0 +1,53 @@
+package bagelpay
+
+import "testing"
+
+func TestGenerateEmbedCode(t *testing.T) {
+	const checkoutURL = "https://pay.bagelpay.io/checkout/pay_123"
+
+	tests := []struct {
+		name string
+		url  string
+		opts EmbedOptions
+		want string
+	}{
+		{
+			name: "iframe with defaults",
+			url:  checkoutURL,
+			want: `<iframe src="https://pay.bagelpay.io/checkout/pay_123?embed=true" width="100%" height="600px" style="border:0" allow="payment" title="BagelPay checkout"></iframe>`,
+		},
+		{
+			name: "iframe with size and theme",
+			url:  checkoutURL,
+			opts: EmbedOptions{Width: "480px", Height: "720px", Theme: "dark"},
+			want: `<iframe src="https://pay.bagelpay.io/checkout/pay_123?embed=true&amp;theme=dark" width="480px" height="720px" style="border:0" allow="payment" title="BagelPay checkout"></iframe>`,
+		},
+		{
+			name: "button",
+			url:  checkoutURL,
+			opts: EmbedOptions{ButtonText: "Buy now", Theme: "light"},
+			want: `<a href="https://pay.bagelpay.io/checkout/pay_123?theme=light" class="bagelpay-checkout-button" target="_blank" rel="noopener">Buy now</a>`,
+		},
+		{
+			name: "escapes values",
+			url:  checkoutURL,
+			opts: EmbedOptions{ButtonText: `<script>alert("x")</script>`},
+			want: `<a href="https://pay.bagelpay.io/checkout/pay_123" class="bagelpay-checkout-button" target="_blank" rel="noopener">&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;</a>`,
+		},
+		{
+			name: "escapes attributes",
+			url:  checkoutURL,
+			opts: EmbedOptions{Width: `100%" onload="alert(1)`},
+			want: `<iframe src="https://pay.bagelpay.io/checkout/pay_123?embed=true" width="100%&#34; onload=&#34;alert(1)" height="600px" style="border:0" allow="payment" title="BagelPay checkout"></iframe>`,
+		},
+		{name: "relative url", url: "/checkout/pay_123", want: ""},
+		{name: "javascript url", url: "javascript:alert(1)", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GenerateEmbedCode(tt.url, tt.opts); got != tt.want {
+				t.Errorf("GenerateEmbedCode() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package bagelpay
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environment variables read by NewClientFromEnv
+const (
+	EnvAPIKey     = "BAGELPAY_API_KEY"
+	EnvMode       = "BAGELPAY_MODE"
+	EnvBaseURL    = "BAGELPAY_BASE_URL"
+	EnvTimeout    = "BAGELPAY_TIMEOUT"
+	EnvMaxRetries = "BAGELPAY_MAX_RETRIES"
+)
+
+// NewClientFromEnv creates a new BagelPay client configured from environment variables.
+// BAGELPAY_API_KEY is required; BAGELPAY_MODE ("test" or "live", default "test"),
+// BAGELPAY_BASE_URL, BAGELPAY_TIMEOUT (e.g. "30s"), and BAGELPAY_MAX_RETRIES are optional.
+func NewClientFromEnv() (*BagelPayClient, error) {
+	config := ClientConfig{
+		APIKey:   strings.TrimSpace(os.Getenv(EnvAPIKey)),
+		TestMode: true,
+		BaseURL:  strings.TrimSpace(os.Getenv(EnvBaseURL)),
+	}
+	if config.APIKey == "" {
+		return nil, NewBagelPayValidationErrorSimple(fmt.Sprintf("%s is not set", EnvAPIKey), nil)
+	}
+
+	switch mode := strings.ToLower(strings.TrimSpace(os.Getenv(EnvMode))); mode {
+	case "", "test":
+	case "live":
+		config.TestMode = false
+	default:
+		return nil, NewBagelPayValidationErrorSimple(fmt.Sprintf("%s must be \"test\" or \"live\", got %q", EnvMode, mode), nil)
+	}
+
+	if value := strings.TrimSpace(os.Getenv(EnvTimeout)); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, NewBagelPayValidationErrorSimple(fmt.Sprintf("%s is not a valid duration: %q", EnvTimeout, value), err)
+		}
+		if timeout <= 0 {
+			return nil, NewBagelPayValidationErrorSimple(fmt.Sprintf("%s must be positive, got %q", EnvTimeout, value), nil)
+		}
+		config.Timeout = timeout
+	}
+
+	if value := strings.TrimSpace(os.Getenv(EnvMaxRetries)); value != "" {
+		maxRetries, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, NewBagelPayValidationErrorSimple(fmt.Sprintf("%s is not a valid integer: %q", EnvMaxRetries, value), err)
+		}
+		if maxRetries < 0 {
+			return nil, NewBagelPayValidationErrorSimple(fmt.Sprintf("%s must not be negative, got %d", EnvMaxRetries, maxRetries), nil)
+		}
+		retry := DefaultRetryConfig()
+		retry.MaxRetries = maxRetries
+		config.Retry = &retry
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return NewClient(config), nil
+}
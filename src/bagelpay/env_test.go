@@ -0,0 +1,90 @@
+package bagelpay
+
+import (
+	"testing"
+	"time"
+)
+
+// setEnv sets the client environment variables, clearing those not in vars
+func setEnv(t *testing.T, vars map[string]string) {
+	t.Helper()
+	for _, key := range []string{EnvAPIKey, EnvMode, EnvBaseURL, EnvTimeout, EnvMaxRetries} {
+		t.Setenv(key, vars[key])
+	}
+}
+
+func TestNewClientFromEnv(t *testing.T) {
+	setEnv(t, map[string]string{
+		EnvAPIKey:     " bagel_live_key_1234 ",
+		EnvMode:       "LIVE",
+		EnvBaseURL:    "https://eu.bagelpay.io/",
+		EnvTimeout:    "5s",
+		EnvMaxRetries: "1",
+	})
+
+	client, err := NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("NewClientFromEnv: %v", err)
+	}
+	if client.apiKey != "bagel_live_key_1234" {
+		t.Errorf("apiKey = %q", client.apiKey)
+	}
+	if !client.IsLiveMode() {
+		t.Error("client is not in live mode")
+	}
+	if client.baseURL != "https://eu.bagelpay.io" {
+		t.Errorf("baseURL = %q", client.baseURL)
+	}
+	if client.timeout != 5*time.Second {
+		t.Errorf("timeout = %s, want 5s", client.timeout)
+	}
+	if client.retry.MaxRetries != 1 {
+		t.Errorf("MaxRetries = %d, want 1", client.retry.MaxRetries)
+	}
+}
+
+func TestNewClientFromEnvDefaultsToTestMode(t *testing.T) {
+	setEnv(t, map[string]string{EnvAPIKey: "bagel_test_key_1234"})
+
+	client, err := NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("NewClientFromEnv: %v", err)
+	}
+	if !client.IsTestMode() || client.baseURL != "https://test.bagelpay.io" {
+		t.Errorf("test mode = %v, baseURL = %q", client.IsTestMode(), client.baseURL)
+	}
+	if client.retry.MaxRetries != DefaultMaxRetries {
+		t.Errorf("MaxRetries = %d, want %d", client.retry.MaxRetries, DefaultMaxRetries)
+	}
+}
+
+func TestNewClientFromEnvErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]string
+	}{
+		{"missing api key", map[string]string{}},
+		{"blank api key", map[string]string{EnvAPIKey: "  "}},
+		{"unknown mode", map[string]string{EnvAPIKey: "k", EnvMode: "staging"}},
+		{"bad timeout", map[string]string{EnvAPIKey: "k", EnvTimeout: "30"}},
+		{"zero timeout", map[string]string{EnvAPIKey: "k", EnvTimeout: "0s"}},
+		{"bad max retries", map[string]string{EnvAPIKey: "k", EnvMaxRetries: "three"}},
+		{"negative max retries", map[string]string{EnvAPIKey: "k", EnvMaxRetries: "-1"}},
+		{"http base url in live mode", map[string]string{EnvAPIKey: "k", EnvMode: "live", EnvBaseURL: "http://eu.bagelpay.io"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnv(t, tt.vars)
+			client, err := NewClientFromEnv()
+			if err == nil {
+				t.Fatalf("NewClientFromEnv returned a client for %v", tt.vars)
+			}
+			if client != nil {
+				t.Error("NewClientFromEnv returned a client alongside the error")
+			}
+			if !IsValidationError(err) {
+				t.Errorf("error %T is not a validation error: %v", err, err)
+			}
+		})
+	}
+}
@@ -1,8 +1,13 @@
 package bagelpay
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"strings"
+	"time"
 )
 
 // BagelPayError represents a base error type for all BagelPay SDK errors
@@ -30,19 +35,47 @@ func NewBagelPayError(message string, cause error) *BagelPayError {
 	}
 }
 
+// Response headers used to correlate errors with BagelPay server logs
+const (
+	RequestIDHeader = "X-Request-ID"
+	TraceIDHeader   = "X-Trace-ID"
+)
+
 // BagelPayAPIError represents an API-specific error
 type BagelPayAPIError struct {
 	*BagelPayError
 	StatusCode int
 	ErrorCode  string
 	APIError   *APIError
+	RequestID  string
+	TraceID    string
 }
 
 func (e *BagelPayAPIError) Error() string {
 	if e.APIError != nil {
-		return fmt.Sprintf("BagelPay API error (status %d): %s", e.StatusCode, e.APIError.Message)
+		return fmt.Sprintf("BagelPay API error (status %d): %s%s", e.StatusCode, e.APIError.Message, e.correlationSuffix())
+	}
+	return fmt.Sprintf("BagelPay API error (status %d): %s%s", e.StatusCode, e.Message, e.correlationSuffix())
+}
+
+// correlationSuffix formats the request and trace IDs for inclusion in error messages
+func (e *BagelPayAPIError) correlationSuffix() string {
+	var ids []string
+	if e.RequestID != "" {
+		ids = append(ids, "request_id: "+e.RequestID)
+	}
+	if e.TraceID != "" {
+		ids = append(ids, "trace_id: "+e.TraceID)
 	}
-	return fmt.Sprintf("BagelPay API error (status %d): %s", e.StatusCode, e.Message)
+	if len(ids) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(ids, ", ") + ")"
+}
+
+// base returns the embedded API error so response metadata can be attached to any subtype
+func (e *BagelPayAPIError) base() *BagelPayAPIError {
+	return e
 }
 
 // String returns a formatted string representation of the error (equivalent to TypeScript toString)
@@ -54,6 +87,12 @@ func (e *BagelPayAPIError) String() string {
 	if e.ErrorCode != "" {
 		parts = append(parts, fmt.Sprintf("Code: %s", e.ErrorCode))
 	}
+	if e.RequestID != "" {
+		parts = append(parts, fmt.Sprintf("Request ID: %s", e.RequestID))
+	}
+	if e.TraceID != "" {
+		parts = append(parts, fmt.Sprintf("Trace ID: %s", e.TraceID))
+	}
 
 	result := parts[0]
 	for i := 1; i < len(parts); i++ {
@@ -87,7 +126,7 @@ type BagelPayAuthenticationError struct {
 }
 
 func (e *BagelPayAuthenticationError) Error() string {
-	return fmt.Sprintf("BagelPay authentication error: %s", e.Message)
+	return fmt.Sprintf("BagelPay authentication error: %s%s", e.Message, e.correlationSuffix())
 }
 
 // NewBagelPayAuthenticationError creates a new BagelPayAuthenticationError
@@ -116,7 +155,7 @@ type BagelPayValidationError struct {
 }
 
 func (e *BagelPayValidationError) Error() string {
-	return fmt.Sprintf("BagelPay validation error: %s", e.Message)
+	return fmt.Sprintf("BagelPay validation error: %s%s", e.Message, e.correlationSuffix())
 }
 
 // NewBagelPayValidationError creates a new BagelPayValidationError
@@ -145,7 +184,7 @@ type BagelPayNotFoundError struct {
 }
 
 func (e *BagelPayNotFoundError) Error() string {
-	return fmt.Sprintf("BagelPay not found error: %s", e.Message)
+	return fmt.Sprintf("BagelPay not found error: %s%s", e.Message, e.correlationSuffix())
 }
 
 // NewBagelPayNotFoundError creates a new BagelPayNotFoundError
@@ -171,14 +210,16 @@ func NewBagelPayNotFoundErrorSimple(message string, cause error) *BagelPayNotFou
 // BagelPayRateLimitError represents rate limit errors
 type BagelPayRateLimitError struct {
 	*BagelPayAPIError
+	// RetryAfter is how long the caller should wait before retrying
+	RetryAfter time.Duration
 }
 
 func (e *BagelPayRateLimitError) Error() string {
-	return fmt.Sprintf("BagelPay rate limit error: %s", e.Message)
+	return fmt.Sprintf("BagelPay rate limit error: %s%s", e.Message, e.correlationSuffix())
 }
 
 // NewBagelPayRateLimitError creates a new BagelPayRateLimitError
-func NewBagelPayRateLimitError(message string, statusCode int, errorCode string, apiError *APIError, cause error) *BagelPayRateLimitError {
+func NewBagelPayRateLimitError(message string, statusCode int, errorCode string, apiError *APIError, retryAfter time.Duration, cause error) *BagelPayRateLimitError {
 	if statusCode == 0 {
 		statusCode = http.StatusTooManyRequests
 	}
@@ -189,12 +230,13 @@ func NewBagelPayRateLimitError(message string, statusCode int, errorCode string,
 			ErrorCode:     errorCode,
 			APIError:      apiError,
 		},
+		RetryAfter: retryAfter,
 	}
 }
 
 // NewBagelPayRateLimitErrorSimple creates a new BagelPayRateLimitError with minimal parameters
 func NewBagelPayRateLimitErrorSimple(message string, cause error) *BagelPayRateLimitError {
-	return NewBagelPayRateLimitError(message, http.StatusTooManyRequests, "", nil, cause)
+	return NewBagelPayRateLimitError(message, http.StatusTooManyRequests, "", nil, 0, cause)
 }
 
 // BagelPayServerError represents server-side errors
@@ -203,7 +245,7 @@ type BagelPayServerError struct {
 }
 
 func (e *BagelPayServerError) Error() string {
-	return fmt.Sprintf("BagelPay server error: %s", e.Message)
+	return fmt.Sprintf("BagelPay server error: %s%s", e.Message, e.correlationSuffix())
 }
 
 // NewBagelPayServerError creates a new BagelPayServerError
@@ -261,3 +303,20 @@ func IsAPIError(err error) bool {
 	_, ok := err.(*BagelPayAPIError)
 	return ok
 }
+
+// IsTemporaryError checks if the error is a rate limit or server error that may succeed later
+func IsTemporaryError(err error) bool {
+	var rateLimitErr *BagelPayRateLimitError
+	var serverErr *BagelPayServerError
+	return errors.As(err, &rateLimitErr) || errors.As(err, &serverErr)
+}
+
+// IsRetryable checks if the request that produced the error is worth retrying:
+// temporary API errors, timeouts, and network errors that report a timeout
+func IsRetryable(err error) bool {
+	if IsTemporaryError(err) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
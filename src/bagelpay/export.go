@@ -0,0 +1,140 @@
+package bagelpay
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// transactionCSVHeader is the header row written by ExportTransactions
+var transactionCSVHeader = []string{
+	"transaction_id", "order_id", "amount", "amount_paid", "currency", "type",
+	"tax_amount", "fees", "net", "customer_email", "created_at",
+}
+
+// ExportTransactions writes every transaction matching the filter to w as RFC 4180 CSV,
+// fetching pages as it goes. filter.MaxItems caps the number of rows when set. Text
+// fields starting with =, +, -, @, tab, or carriage return are prefixed with ' so that
+// spreadsheets do not run them as formulas.
+func (c *BagelPayClient) ExportTransactions(ctx context.Context, filter TransactionFilter, w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.UseCRLF = true
+	if err := cw.Write(transactionCSVHeader); err != nil {
+		return NewBagelPayError("failed to write CSV", err)
+	}
+
+	it := newListIter(ctx, listAllPageSize, c.transactionPages(filter))
+	for rows := 0; (filter.MaxItems <= 0 || rows < filter.MaxItems) && it.next(); rows++ {
+		t := it.current
+		var customerEmail *string
+		if t.Customer != nil {
+			customerEmail = t.Customer.Email
+		}
+		var txType *string
+		if t.Type != nil {
+			txType = StringPtr(string(*t.Type))
+		}
+
+		if err := cw.Write([]string{
+			csvString(t.TransactionID),
+			csvString(t.OrderID),
+			csvFloat(t.Amount),
+			csvFloat(t.AmountPaid),
+			csvString(t.Currency),
+			csvString(txType),
+			csvFloat(t.TaxAmount),
+			csvFloat(t.Fees),
+			csvFloat(t.Net),
+			csvString(customerEmail),
+			csvString(t.CreatedAt),
+		}); err != nil {
+			return NewBagelPayError("failed to write CSV", err)
+		}
+	}
+	if it.err != nil {
+		return it.err
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return NewBagelPayError("failed to write CSV", err)
+	}
+	return nil
+}
+
+// customerCSVHeader is the header row written by ExportCustomers
+var customerCSVHeader = []string{
+	"id", "email", "name", "total_spend", "subscriptions", "payments", "created_at",
+}
+
+// ExportCustomers writes every customer to w as RFC 4180 CSV, fetching pages as it goes
+func (c *BagelPayClient) ExportCustomers(ctx context.Context, w io.Writer) error {
+	return c.ExportCustomersFiltered(ctx, CustomerFilter{}, w)
+}
+
+// ExportCustomersFiltered writes every customer matching the filter to w as RFC 4180 CSV.
+// filter.MaxItems caps the number of rows when set. Names and emails come from your
+// customers, so those starting with =, +, -, @, tab, or carriage return are prefixed
+// with ' to keep spreadsheets and CRM imports from running them as formulas.
+func (c *BagelPayClient) ExportCustomersFiltered(ctx context.Context, filter CustomerFilter, w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.UseCRLF = true
+	if err := cw.Write(customerCSVHeader); err != nil {
+		return NewBagelPayError("failed to write CSV", err)
+	}
+
+	it := newListIter(ctx, listAllPageSize, c.customerPages(filter))
+	for rows := 0; (filter.MaxItems <= 0 || rows < filter.MaxItems) && it.next(); rows++ {
+		customer := it.current
+		if err := cw.Write([]string{
+			csvInt(customer.ID),
+			csvString(customer.Email),
+			csvString(customer.Name),
+			csvFloat(customer.TotalSpend),
+			csvInt(customer.Subscriptions),
+			csvInt(customer.Payments),
+			csvString(customer.CreatedAt),
+		}); err != nil {
+			return NewBagelPayError("failed to write CSV", err)
+		}
+	}
+	if it.err != nil {
+		return it.err
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return NewBagelPayError("failed to write CSV", err)
+	}
+	return nil
+}
+
+// csvString formats an optional string as a CSV field. Text that a spreadsheet would
+// evaluate as a formula is prefixed with a single quote so that it is shown as text.
+func csvString(s *string) string {
+	if s == nil || *s == "" {
+		return ""
+	}
+	if strings.ContainsRune("=+-@\t\r", rune((*s)[0])) {
+		return "'" + *s
+	}
+	return *s
+}
+
+// csvFloat formats an optional number as a CSV field
+func csvFloat(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f, 'f', -1, 64)
+}
+
+// csvInt formats an optional integer as a CSV field
+func csvInt(i *int) string {
+	if i == nil {
+		return ""
+	}
+	return strconv.Itoa(*i)
+}
@@ -0,0 +1,157 @@
+package bagelpay
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// pagedServer serves pages in order by pageNum, reporting more while pages remain
+func pagedServer(t *testing.T, pages []string, requests *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		var pageNum int
+		fmt.Sscan(r.URL.Query().Get("pageNum"), &pageNum)
+		if pageNum < 1 || pageNum > len(pages) {
+			t.Errorf("unexpected pageNum %q", r.URL.Query().Get("pageNum"))
+			fmt.Fprint(w, `{"items":[]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"items":%s,"has_more":%t}`, pages[pageNum-1], pageNum < len(pages))
+	}
+}
+
+var transactionPagesFixture = []string{
+	`[
+		{"transaction_id":"txn_1","order_id":"ord,1","amount":10.5,"amount_paid":10.5,"currency":"USD","type":"payment",
+		 "tax_amount":0.5,"fees":0.3,"net":9.7,"customer":{"email":"\"ada\"@example.com"},"created_at":"2024-01-02T03:04:05Z"},
+		{"transaction_id":"txn_2","amount":5,"currency":"EUR","type":"refund"}
+	]`,
+	`[
+		{"transaction_id":"txn_3","order_id":"ord_3","amount":1.25,"currency":"USD","created_at":"2024-01-03T00:00:00Z"}
+	]`,
+}
+
+func TestExportTransactions(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxItems     int
+		want         string
+		wantRequests int
+	}{
+		{
+			name: "all pages",
+			want: "transaction_id,order_id,amount,amount_paid,currency,type,tax_amount,fees,net,customer_email,created_at\r\n" +
+				"txn_1,\"ord,1\",10.5,10.5,USD,payment,0.5,0.3,9.7,\"\"\"ada\"\"@example.com\",2024-01-02T03:04:05Z\r\n" +
+				"txn_2,,5,,EUR,refund,,,,,\r\n" +
+				"txn_3,ord_3,1.25,,USD,,,,,,2024-01-03T00:00:00Z\r\n",
+			wantRequests: 2,
+		},
+		{
+			name:     "max items",
+			maxItems: 1,
+			want: "transaction_id,order_id,amount,amount_paid,currency,type,tax_amount,fees,net,customer_email,created_at\r\n" +
+				"txn_1,\"ord,1\",10.5,10.5,USD,payment,0.5,0.3,9.7,\"\"\"ada\"\"@example.com\",2024-01-02T03:04:05Z\r\n",
+			wantRequests: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			client := newTestClient(t, pagedServer(t, transactionPagesFixture, &requests))
+
+			var out bytes.Buffer
+			if err := client.ExportTransactions(context.Background(), TransactionFilter{MaxItems: tt.maxItems}, &out); err != nil {
+				t.Fatalf("ExportTransactions: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("CSV =\n%q\nwant\n%q", out.String(), tt.want)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("made %d requests, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestExportTransactionsEmpty(t *testing.T) {
+	var requests int
+	client := newTestClient(t, pagedServer(t, []string{`[]`}, &requests))
+
+	var out bytes.Buffer
+	if err := client.ExportTransactions(context.Background(), TransactionFilter{}, &out); err != nil {
+		t.Fatalf("ExportTransactions: %v", err)
+	}
+	if want := "transaction_id,order_id,amount,amount_paid,currency,type,tax_amount,fees,net,customer_email,created_at\r\n"; out.String() != want {
+		t.Errorf("CSV = %q, want only the header", out.String())
+	}
+}
+
+func TestExportCustomersFiltered(t *testing.T) {
+	pages := []string{
+		`[
+			{"id":1,"email":"ada@example.com","name":"Lovelace, Ada","total_spend":120.5,"subscriptions":1,"payments":3,"created_at":"2024-01-02T03:04:05Z"},
+			{"id":2,"email":"@evil.example.com","name":"=HYPERLINK(\"http://x\")","total_spend":-5}
+		]`,
+		`[
+			{"id":3,"email":"+1@example.com","name":"-minus","created_at":"2024-02-01T00:00:00Z"}
+		]`,
+	}
+	header := "id,email,name,total_spend,subscriptions,payments,created_at\r\n"
+	rows := []string{
+		"1,ada@example.com,\"Lovelace, Ada\",120.5,1,3,2024-01-02T03:04:05Z\r\n",
+		"2,'@evil.example.com,\"'=HYPERLINK(\"\"http://x\"\")\",-5,,,\r\n",
+		"3,'+1@example.com,'-minus,,,,2024-02-01T00:00:00Z\r\n",
+	}
+
+	tests := []struct {
+		name         string
+		maxItems     int
+		want         string
+		wantRequests int
+	}{
+		{"all pages", 0, header + rows[0] + rows[1] + rows[2], 2},
+		{"max items", 2, header + rows[0] + rows[1], 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			client := newTestClient(t, pagedServer(t, pages, &requests))
+
+			var out bytes.Buffer
+			if err := client.ExportCustomersFiltered(context.Background(), CustomerFilter{MaxItems: tt.maxItems}, &out); err != nil {
+				t.Fatalf("ExportCustomersFiltered: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("CSV =\n%q\nwant\n%q", out.String(), tt.want)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("made %d requests, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestCSVStringEscapesFormulas(t *testing.T) {
+	tests := map[string]string{
+		"":             "",
+		"plain":        "plain",
+		"=1+1":         "'=1+1",
+		"+44 20":       "'+44 20",
+		"-2":           "'-2",
+		"@SUM(A1)":     "'@SUM(A1)",
+		"\tindented":   "'\tindented",
+		"a=b":          "a=b",
+		"ada@mail.com": "ada@mail.com",
+	}
+	for in, want := range tests {
+		if got := csvString(&in); got != want {
+			t.Errorf("csvString(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if got := csvString(nil); got != "" {
+		t.Errorf("csvString(nil) = %q", got)
+	}
+}
@@ -0,0 +1,51 @@
+package bagelpay
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// formatCurrency formats amount in the ISO 4217 currency code using the number
+// conventions of locale (a BCP 47 tag such as "en-US" or "de-DE"). Unparseable
+// locales fall back to English; unknown currencies are reported as an error.
+func formatCurrency(amount float64, code, locale string) (string, error) {
+	unit, err := currency.ParseISO(strings.TrimSpace(code))
+	if err != nil {
+		return "", newValidationError(fmt.Sprintf("unrecognised currency %q", code))
+	}
+	printer := message.NewPrinter(language.Make(locale))
+	return printer.Sprint(currency.Symbol(unit.Amount(amount))), nil
+}
+
+// FormattedAmount formats Amount in Currency for display in the given locale,
+// e.g. "$ 1,234.50" for "en-US" or "€ 1.234,50" for "de-DE". Missing amounts
+// format as zero; unknown currencies fall back to the number followed by the code.
+func (t Transaction) FormattedAmount(locale string) string {
+	amount := derefFloat(t.Amount)
+	var code string
+	if t.Currency != nil {
+		code = *t.Currency
+	}
+	if s, err := formatCurrency(amount, code, locale); err == nil {
+		return s
+	}
+	return strings.TrimSpace(message.NewPrinter(language.Make(locale)).Sprintf("%.2f %s", amount, code))
+}
+
+// FormattedPrice formats Price in Currency for display in the given locale,
+// e.g. "$ 9.99" for "en-US". It returns an error when the price is missing or
+// the currency is not a recognised ISO 4217 code.
+func (p Product) FormattedPrice(locale string) (string, error) {
+	if p.Price == nil {
+		return "", newValidationError("price is missing")
+	}
+	var code string
+	if p.Currency != nil {
+		code = *p.Currency
+	}
+	return formatCurrency(*p.Price, code, locale)
+}
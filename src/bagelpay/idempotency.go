@@ -0,0 +1,43 @@
+package bagelpay
+
+// IdempotencyKeyHeader is the HTTP header carrying the idempotency key.
+// The API returns the cached response for a repeated key within its idempotency
+// window, so a failed request can be retried safely without double-charging.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// idempotentRequest is implemented by request bodies that can carry an idempotency key
+type idempotentRequest interface {
+	idempotencyKey() *string
+}
+
+func (r CheckoutRequest) idempotencyKey() *string           { return r.IdempotencyKey }
+func (r CartCheckoutRequest) idempotencyKey() *string       { return r.IdempotencyKey }
+func (r CreateProductRequest) idempotencyKey() *string      { return r.IdempotencyKey }
+func (r UpdateProductRequest) idempotencyKey() *string      { return r.IdempotencyKey }
+func (r PatchProductRequest) idempotencyKey() *string       { return r.IdempotencyKey }
+func (r ProductOverrides) idempotencyKey() *string          { return r.IdempotencyKey }
+func (r CouponRequest) idempotencyKey() *string             { return r.IdempotencyKey }
+func (r PaymentLinkOptions) idempotencyKey() *string        { return r.IdempotencyKey }
+func (r RefundRequest) idempotencyKey() *string             { return r.IdempotencyKey }
+func (r DisputeEvidence) idempotencyKey() *string           { return r.IdempotencyKey }
+func (r CancelSubscriptionOptions) idempotencyKey() *string { return r.IdempotencyKey }
+func (r PauseRequest) idempotencyKey() *string              { return r.IdempotencyKey }
+func (r ChangePlanRequest) idempotencyKey() *string         { return r.IdempotencyKey }
+func (r CreateCustomerRequest) idempotencyKey() *string     { return r.IdempotencyKey }
+func (r UpdateCustomerRequest) idempotencyKey() *string     { return r.IdempotencyKey }
+func (r WebhookRequest) idempotencyKey() *string            { return r.IdempotencyKey }
+
+// idempotencyKeyFor returns the key to send with a request. POST requests without
+// an explicit key get a generated one when retries are enabled, so that every
+// retry of the same call shares a key.
+func (c *BagelPayClient) idempotencyKeyFor(method string, data interface{}) (string, error) {
+	if request, ok := data.(idempotentRequest); ok {
+		if key := request.idempotencyKey(); key != nil {
+			return *key, nil
+		}
+	}
+	if method != "POST" || c.retry.MaxRetries == 0 {
+		return "", nil
+	}
+	return newUUID()
+}
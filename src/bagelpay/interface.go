@@ -0,0 +1,125 @@
+package bagelpay
+
+import "context"
+
+// BagelPayClientInterface is the set of API operations offered by BagelPayClient.
+// Depend on it instead of *BagelPayClient to swap in a mock in unit tests.
+type BagelPayClientInterface interface {
+	// Health
+	Ping(ctx context.Context) error
+	ValidateAPIKey(ctx context.Context) (*APIKeyInfo, error)
+
+	// Checkout
+	CreateCheckout(ctx context.Context, request CheckoutRequest) (*CheckoutResponse, error)
+	CreateCartCheckout(ctx context.Context, request CartCheckoutRequest) (*CheckoutResponse, error)
+	GetCheckout(ctx context.Context, paymentID string) (*CheckoutResponse, error)
+	CancelCheckout(ctx context.Context, paymentID string) (*CheckoutResponse, error)
+	ListCheckouts(ctx context.Context, filter CheckoutFilter, pageNum, pageSize int) (*CheckoutListResponse, error)
+	ListSupportedLocales(ctx context.Context) ([]string, error)
+
+	// Coupons
+	CreateCoupon(ctx context.Context, request CouponRequest) (*Coupon, error)
+	GetCoupon(ctx context.Context, code string) (*Coupon, error)
+	ListCoupons(ctx context.Context, pageNum, pageSize int) (*CouponListResponse, error)
+	ExpireCoupon(ctx context.Context, code string) error
+
+	// Payment links
+	GeneratePaymentLink(ctx context.Context, productID string, opts PaymentLinkOptions) (*PaymentLink, error)
+	GetPaymentLink(ctx context.Context, linkID string) (*PaymentLink, error)
+	ListPaymentLinks(ctx context.Context, pageNum, pageSize int) (*PaymentLinkListResponse, error)
+	DeletePaymentLink(ctx context.Context, linkID string) error
+
+	// Products
+	CreateProduct(ctx context.Context, request CreateProductRequest) (*Product, error)
+	GetProduct(ctx context.Context, productID string) (*Product, error)
+	ListProducts(ctx context.Context, filter ProductFilter, pageNum, pageSize int) (*ProductListResponse, error)
+	SearchProducts(ctx context.Context, query string, pageNum, pageSize int) (*ProductListResponse, error)
+	ListProductCategories(ctx context.Context) ([]string, error)
+	UpdateProduct(ctx context.Context, request UpdateProductRequest) (*Product, error)
+	PatchProduct(ctx context.Context, request PatchProductRequest) (*Product, error)
+	DuplicateProduct(ctx context.Context, productID string, overrides *ProductOverrides) (*Product, error)
+	ArchiveProduct(ctx context.Context, productID string) (*Product, error)
+	UnarchiveProduct(ctx context.Context, productID string) (*Product, error)
+	BulkArchiveProducts(ctx context.Context, productIDs []string) (*BulkOperationResult, error)
+	BulkUnarchiveProducts(ctx context.Context, productIDs []string) (*BulkOperationResult, error)
+
+	// Transactions
+	ListTransactions(ctx context.Context, filter TransactionFilter, pageNum, pageSize int) (*TransactionListResponse, error)
+	ListProductTransactions(ctx context.Context, productID string, pageNum, pageSize int) (*TransactionListResponse, error)
+	ListSubscriptionTransactions(ctx context.Context, subscriptionID string, pageNum, pageSize int) (*TransactionListResponse, error)
+	GetTransaction(ctx context.Context, transactionID string) (*Transaction, error)
+
+	// Refunds
+	CreateRefund(ctx context.Context, request RefundRequest) (*Refund, error)
+	ListRefunds(ctx context.Context, transactionID string, pageNum, pageSize int) (*RefundListResponse, error)
+
+	// Disputes
+	ListDisputes(ctx context.Context, filter DisputeFilter, pageNum, pageSize int) (*DisputeListResponse, error)
+	GetDispute(ctx context.Context, disputeID string) (*Dispute, error)
+	SubmitDisputeEvidence(ctx context.Context, disputeID string, evidence DisputeEvidence) (*Dispute, error)
+
+	// Invoices
+	ListInvoices(ctx context.Context, filter InvoiceFilter, pageNum, pageSize int) (*InvoiceListResponse, error)
+	ListSubscriptionInvoices(ctx context.Context, subscriptionID string, pageNum, pageSize int) (*InvoiceListResponse, error)
+	GetInvoice(ctx context.Context, invoiceID string) (*Invoice, error)
+
+	// Subscriptions
+	ListSubscriptions(ctx context.Context, filter SubscriptionFilter, pageNum, pageSize int) (*SubscriptionListResponse, error)
+	GetCustomerSubscriptions(ctx context.Context, customerEmail string, pageNum, pageSize int) (*SubscriptionListResponse, error)
+	GetSubscription(ctx context.Context, subscriptionID string) (*Subscription, error)
+	CancelSubscription(ctx context.Context, subscriptionID string, opts CancelSubscriptionOptions) (*Subscription, error)
+	BulkCancelSubscriptions(ctx context.Context, subscriptionIDs []string, opts CancelSubscriptionOptions) (*BulkOperationResult, error)
+	ReactivateSubscription(ctx context.Context, subscriptionID string) (*Subscription, error)
+	PauseSubscription(ctx context.Context, subscriptionID string, request PauseRequest) (*Subscription, error)
+	ResumeSubscription(ctx context.Context, subscriptionID string) (*Subscription, error)
+	ChangeSubscriptionPlan(ctx context.Context, subscriptionID string, request ChangePlanRequest) (*Subscription, error)
+	PreviewChangePlan(ctx context.Context, subscriptionID string, request ChangePlanRequest) (*ProrationPreview, error)
+	ExtendTrial(ctx context.Context, subscriptionID string, additionalDays int) (*Subscription, error)
+
+	// Customers
+	ListCustomers(ctx context.Context, filter CustomerFilter, pageNum, pageSize int) (*CustomerListResponse, error)
+	SearchCustomers(ctx context.Context, query string, pageNum, pageSize int) (*CustomerListResponse, error)
+	GetCustomer(ctx context.Context, customerID int) (*CustomerData, error)
+	CreateCustomer(ctx context.Context, request CreateCustomerRequest) (*CustomerData, error)
+	UpdateCustomer(ctx context.Context, request UpdateCustomerRequest) (*CustomerData, error)
+	DeleteCustomer(ctx context.Context, customerID int) error
+	CreateCustomerPortalSession(ctx context.Context, customerID int, returnURL string) (*CustomerPortalSession, error)
+	GetCustomerPortalURL(ctx context.Context, customerID int, returnURL string) (string, error)
+
+	// Payment methods
+	ListPaymentMethods(ctx context.Context, customerID int) ([]PaymentMethod, error)
+	DeletePaymentMethod(ctx context.Context, paymentMethodID string) error
+	SetDefaultPaymentMethod(ctx context.Context, customerID int, paymentMethodID string) error
+	ListSupportedPaymentMethods(ctx context.Context) ([]SupportedPaymentMethod, error)
+
+	// Currencies
+	ListSupportedCurrencies(ctx context.Context) ([]Currency, error)
+
+	// Tax
+	ListTaxCategories(ctx context.Context, country *string) ([]TaxCategory, error)
+	GetTaxRate(ctx context.Context, request TaxRateRequest) (*TaxRate, error)
+
+	// Webhooks
+	CreateWebhook(ctx context.Context, request WebhookRequest) (*Webhook, error)
+	ListWebhooks(ctx context.Context) ([]Webhook, error)
+	DeleteWebhook(ctx context.Context, webhookID string) error
+	TriggerTestWebhook(ctx context.Context, webhookID string, eventType string) error
+	ListWebhookEvents(ctx context.Context, webhookID string, pageNum, pageSize int) (*WebhookEventListResponse, error)
+	GetWebhookEvent(ctx context.Context, eventID string) (*WebhookEvent, error)
+	ResendWebhookEvent(ctx context.Context, eventID string) error
+
+	// Analytics
+	GetRevenueSummary(ctx context.Context, period string) (*RevenueSummary, error)
+
+	// Balance
+	GetBalance(ctx context.Context) (*Balance, error)
+
+	// Payouts
+	ListPayouts(ctx context.Context, pageNum, pageSize int) (*PayoutListResponse, error)
+	GetPayout(ctx context.Context, payoutID string) (*Payout, error)
+
+	// Store
+	GetStoreInfo(ctx context.Context) (*StoreInfo, error)
+}
+
+var _ BagelPayClientInterface = (*BagelPayClient)(nil)
@@ -0,0 +1,364 @@
+package bagelpay
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultPageSize is the page size used by iterators when none is given
+const DefaultPageSize = 20
+
+// listPage is a single page of a list endpoint
+type listPage[T any] struct {
+	items         []T
+	hasMore       bool
+	nextPageToken *string
+}
+
+// cursor returns the token for the following page, or nil when the API did not send one
+func (p listPage[T]) cursor() *string {
+	if p.nextPageToken == nil || *p.nextPageToken == "" {
+		return nil
+	}
+	return p.nextPageToken
+}
+
+// isLast reports whether no page follows this one. A cursor or HasMore means
+// there is more; otherwise a short page means there is nothing left to fetch.
+func (p listPage[T]) isLast(pageSize int) bool {
+	if len(p.items) == 0 {
+		return true
+	}
+	if p.cursor() != nil || p.hasMore {
+		return false
+	}
+	return len(p.items) < pageSize
+}
+
+// pageFetcher fetches a single page of items. pageToken is the cursor returned with
+// the previous page and takes precedence over pageNum when it is not nil.
+type pageFetcher[T any] func(ctx context.Context, pageNum, pageSize int, pageToken *string) (listPage[T], error)
+
+// listIter walks through every page of a list endpoint one item at a time
+type listIter[T any] struct {
+	ctx       context.Context
+	fetch     pageFetcher[T]
+	pageNum   int
+	pageSize  int
+	pageToken *string
+	items     []T
+	index     int
+	current   T
+	lastPage  bool
+	err       error
+}
+
+func newListIter[T any](ctx context.Context, pageSize int, fetch pageFetcher[T]) listIter[T] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return listIter[T]{
+		ctx:      ctx,
+		fetch:    fetch,
+		pageSize: pageSize,
+	}
+}
+
+// next advances to the next item, fetching a new page when the current one is exhausted
+func (it *listIter[T]) next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	for it.index >= len(it.items) {
+		if it.lastPage {
+			return false
+		}
+
+		it.pageNum++
+		page, err := it.fetch(it.ctx, it.pageNum, it.pageSize, it.pageToken)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.items = page.items
+		it.index = 0
+		it.pageToken = page.cursor()
+		it.lastPage = page.isLast(it.pageSize)
+	}
+
+	it.current = it.items[it.index]
+	it.index++
+	return true
+}
+
+// productPages returns a pageFetcher for the products matching the filter
+func (c *BagelPayClient) productPages(filter ProductFilter) pageFetcher[Product] {
+	return func(ctx context.Context, pageNum, pageSize int, pageToken *string) (listPage[Product], error) {
+		// Clear a cursor left over from an earlier page when paging by number
+		filter.PageToken = pageToken
+		resp, err := c.ListProducts(ctx, filter, pageNum, pageSize)
+		if err != nil {
+			return listPage[Product]{}, err
+		}
+		return listPage[Product]{items: resp.Items, hasMore: resp.HasMore, nextPageToken: resp.NextPageToken}, nil
+	}
+}
+
+// subscriptionPages returns a pageFetcher for the subscriptions matching the filter
+func (c *BagelPayClient) subscriptionPages(filter SubscriptionFilter) pageFetcher[Subscription] {
+	return func(ctx context.Context, pageNum, pageSize int, pageToken *string) (listPage[Subscription], error) {
+		// Clear a cursor left over from an earlier page when paging by number
+		filter.PageToken = pageToken
+		resp, err := c.ListSubscriptions(ctx, filter, pageNum, pageSize)
+		if err != nil {
+			return listPage[Subscription]{}, err
+		}
+		return listPage[Subscription]{items: resp.Items, hasMore: resp.HasMore, nextPageToken: resp.NextPageToken}, nil
+	}
+}
+
+// transactionPages returns a pageFetcher for the transactions matching the filter
+func (c *BagelPayClient) transactionPages(filter TransactionFilter) pageFetcher[Transaction] {
+	return func(ctx context.Context, pageNum, pageSize int, pageToken *string) (listPage[Transaction], error) {
+		// Clear a cursor left over from an earlier page when paging by number
+		filter.PageToken = pageToken
+		resp, err := c.ListTransactions(ctx, filter, pageNum, pageSize)
+		if err != nil {
+			return listPage[Transaction]{}, err
+		}
+		return listPage[Transaction]{items: resp.Items, hasMore: resp.HasMore, nextPageToken: resp.NextPageToken}, nil
+	}
+}
+
+// customerPages returns a pageFetcher for the customers matching the filter
+func (c *BagelPayClient) customerPages(filter CustomerFilter) pageFetcher[CustomerData] {
+	return func(ctx context.Context, pageNum, pageSize int, pageToken *string) (listPage[CustomerData], error) {
+		// Clear a cursor left over from an earlier page when paging by number
+		filter.PageToken = pageToken
+		resp, err := c.ListCustomers(ctx, filter, pageNum, pageSize)
+		if err != nil {
+			return listPage[CustomerData]{}, err
+		}
+		return listPage[CustomerData]{items: resp.Items, hasMore: resp.HasMore, nextPageToken: resp.NextPageToken}, nil
+	}
+}
+
+// ProductIter iterates over all products, fetching pages on demand
+type ProductIter struct {
+	it listIter[Product]
+}
+
+// Next advances the iterator and reports whether a product is available
+func (i *ProductIter) Next() bool { return i.it.next() }
+
+// Product returns the current product
+func (i *ProductIter) Product() Product { return i.it.current }
+
+// Err returns the error, if any, that stopped the iteration
+func (i *ProductIter) Err() error { return i.it.err }
+
+// Products returns an iterator over all products
+func (c *BagelPayClient) Products(ctx context.Context, pageSize int) *ProductIter {
+	return &ProductIter{it: newListIter(ctx, pageSize, c.productPages(ProductFilter{}))}
+}
+
+// SubscriptionIter iterates over all subscriptions, fetching pages on demand
+type SubscriptionIter struct {
+	it listIter[Subscription]
+}
+
+// Next advances the iterator and reports whether a subscription is available
+func (i *SubscriptionIter) Next() bool { return i.it.next() }
+
+// Subscription returns the current subscription
+func (i *SubscriptionIter) Subscription() Subscription { return i.it.current }
+
+// Err returns the error, if any, that stopped the iteration
+func (i *SubscriptionIter) Err() error { return i.it.err }
+
+// Subscriptions returns an iterator over all subscriptions
+func (c *BagelPayClient) Subscriptions(ctx context.Context, pageSize int) *SubscriptionIter {
+	return &SubscriptionIter{it: newListIter(ctx, pageSize, c.subscriptionPages(SubscriptionFilter{}))}
+}
+
+// TransactionIter iterates over all transactions, fetching pages on demand
+type TransactionIter struct {
+	it listIter[Transaction]
+}
+
+// Next advances the iterator and reports whether a transaction is available
+func (i *TransactionIter) Next() bool { return i.it.next() }
+
+// Transaction returns the current transaction
+func (i *TransactionIter) Transaction() Transaction { return i.it.current }
+
+// Err returns the error, if any, that stopped the iteration
+func (i *TransactionIter) Err() error { return i.it.err }
+
+// Transactions returns an iterator over all transactions
+func (c *BagelPayClient) Transactions(ctx context.Context, pageSize int) *TransactionIter {
+	return &TransactionIter{it: newListIter(ctx, pageSize, c.transactionPages(TransactionFilter{}))}
+}
+
+// CustomerIter iterates over all customers, fetching pages on demand
+type CustomerIter struct {
+	it listIter[CustomerData]
+}
+
+// Next advances the iterator and reports whether a customer is available
+func (i *CustomerIter) Next() bool { return i.it.next() }
+
+// Customer returns the current customer
+func (i *CustomerIter) Customer() CustomerData { return i.it.current }
+
+// Err returns the error, if any, that stopped the iteration
+func (i *CustomerIter) Err() error { return i.it.err }
+
+// Customers returns an iterator over all customers
+func (c *BagelPayClient) Customers(ctx context.Context, pageSize int) *CustomerIter {
+	return &CustomerIter{it: newListIter(ctx, pageSize, c.customerPages(CustomerFilter{}))}
+}
+
+// listAllPageSize is the page size used by the ListAll helpers
+const listAllPageSize = 100
+
+// listAll fetches pages until the last one or until maxItems items are collected
+func listAll[T any](ctx context.Context, maxItems int, fetch pageFetcher[T]) ([]T, error) {
+	var all []T
+	var pageToken *string
+	for pageNum := 1; ; pageNum++ {
+		page, err := fetch(ctx, pageNum, listAllPageSize, pageToken)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, page.items...)
+		if maxItems > 0 && len(all) >= maxItems {
+			return all[:maxItems], nil
+		}
+		if page.isLast(listAllPageSize) {
+			return all, nil
+		}
+		pageToken = page.cursor()
+	}
+}
+
+// ListAllProducts fetches every product matching the filter, up to filter.MaxItems
+func (c *BagelPayClient) ListAllProducts(ctx context.Context, filter ProductFilter) ([]Product, error) {
+	return listAll(ctx, filter.MaxItems, c.productPages(filter))
+}
+
+// ListAllSubscriptions fetches every subscription matching the filter, up to filter.MaxItems
+func (c *BagelPayClient) ListAllSubscriptions(ctx context.Context, filter SubscriptionFilter) ([]Subscription, error) {
+	return listAll(ctx, filter.MaxItems, c.subscriptionPages(filter))
+}
+
+// ListAllTransactions fetches every transaction matching the filter, up to filter.MaxItems
+func (c *BagelPayClient) ListAllTransactions(ctx context.Context, filter TransactionFilter) ([]Transaction, error) {
+	return listAll(ctx, filter.MaxItems, c.transactionPages(filter))
+}
+
+// ListAllTransactionsConcurrent fetches every transaction matching the filter, up to
+// filter.MaxItems, like ListAllTransactions but with up to concurrency pages in flight.
+// It fetches the first page to learn Total and then the remaining pages in parallel by
+// page number, so filter.PageToken is ignored. Transactions are returned sorted by
+// CreatedAt, oldest first unless filter.SortOrder is SortOrderDesc.
+//
+// Pages are read independently, so the result is not a consistent snapshot: a
+// transaction created during the call shifts later items between pages. Items seen twice
+// are dropped by TransactionID, but an item shifted past a page boundary may be missed;
+// use ListAllTransactions when a consistent walk matters more than speed.
+func (c *BagelPayClient) ListAllTransactionsConcurrent(ctx context.Context, filter TransactionFilter, concurrency int) ([]Transaction, error) {
+	if concurrency < 1 {
+		return nil, newValidationError(fmt.Sprintf("concurrency must be at least 1, got %d", concurrency))
+	}
+	filter.PageToken = nil
+
+	first, err := c.ListTransactions(ctx, filter, 1, listAllPageSize)
+	if err != nil {
+		return nil, err
+	}
+	total := first.Total
+	if filter.MaxItems > 0 && filter.MaxItems < total {
+		total = filter.MaxItems
+	}
+	pageCount := (total + listAllPageSize - 1) / listAllPageSize
+
+	pages := make([][]Transaction, max(pageCount, 1))
+	pages[0] = first.Items
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+	for pageNum := 2; pageNum <= pageCount; pageNum++ {
+		pageNum := pageNum
+		g.Go(func() error {
+			resp, err := c.ListTransactions(gctx, filter, pageNum, listAllPageSize)
+			if err != nil {
+				return err
+			}
+			pages[pageNum-1] = resp.Items
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	all := make([]Transaction, 0, total)
+	seen := make(map[string]bool, total)
+	for _, items := range pages {
+		for _, t := range items {
+			if t.TransactionID != nil {
+				if seen[*t.TransactionID] {
+					continue
+				}
+				seen[*t.TransactionID] = true
+			}
+			all = append(all, t)
+		}
+	}
+	if filter.MaxItems > 0 && len(all) > filter.MaxItems {
+		all = all[:filter.MaxItems]
+	}
+	sortTransactionsByCreatedAt(all, filter.SortOrder != nil && *filter.SortOrder == SortOrderDesc)
+	return all, nil
+}
+
+// sortTransactionsByCreatedAt sorts transactions by creation time in place. Transactions
+// without a parseable CreatedAt go last, keeping their relative order.
+func sortTransactionsByCreatedAt(transactions []Transaction, descending bool) {
+	type keyed struct {
+		transaction Transaction
+		createdAt   time.Time
+		ok          bool
+	}
+	keys := make([]keyed, len(transactions))
+	for i, t := range transactions {
+		createdAt, err := parseTimestamp(t.CreatedAt)
+		keys[i] = keyed{transaction: t, createdAt: createdAt, ok: err == nil}
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if !a.ok || !b.ok {
+			return a.ok && !b.ok
+		}
+		if descending {
+			return a.createdAt.After(b.createdAt)
+		}
+		return a.createdAt.Before(b.createdAt)
+	})
+	for i, k := range keys {
+		transactions[i] = k.transaction
+	}
+}
+
+// ListAllCustomers fetches every customer matching the filter, up to filter.MaxItems
+func (c *BagelPayClient) ListAllCustomers(ctx context.Context, filter CustomerFilter) ([]CustomerData, error) {
+	return listAll(ctx, filter.MaxItems, c.customerPages(filter))
+}
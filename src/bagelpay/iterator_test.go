@@ -0,0 +1,198 @@
+package bagelpay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestIteratorDropsCursorWhenPageHasNone(t *testing.T) {
+	var mu sync.Mutex
+	var queries []url.Values
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.Query())
+		call := len(queries)
+		mu.Unlock()
+
+		switch call {
+		case 1:
+			fmt.Fprint(w, `{"items":[{"transaction_id":"t1"},{"transaction_id":"t2"}],"has_more":true,"next_page_token":"cursor-2"}`)
+		case 2:
+			// No cursor: the client must fall back to page numbers
+			fmt.Fprint(w, `{"items":[{"transaction_id":"t3"},{"transaction_id":"t4"}],"has_more":true}`)
+		default:
+			fmt.Fprint(w, `{"items":[{"transaction_id":"t5"}],"has_more":false}`)
+		}
+	})
+
+	it := client.Transactions(context.Background(), 2)
+	var ids []string
+	for it.Next() {
+		ids = append(ids, *it.Transaction().TransactionID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("iteration failed: %v", err)
+	}
+	if len(ids) != 5 {
+		t.Fatalf("got %d transactions %v, want 5", len(ids), ids)
+	}
+	if len(queries) != 3 {
+		t.Fatalf("got %d requests, want 3", len(queries))
+	}
+
+	if got := queries[1].Get("pageToken"); got != "cursor-2" {
+		t.Errorf("page 2 pageToken = %q, want cursor-2", got)
+	}
+	page3 := queries[2]
+	if page3.Has("pageToken") {
+		t.Errorf("page 3 resent stale pageToken %q", page3.Get("pageToken"))
+	}
+	if got := page3.Get("pageNum"); got != "3" {
+		t.Errorf("page 3 pageNum = %q, want 3", got)
+	}
+}
+
+// transactionPageServer serves total transactions by page number. Creation times are
+// scrambled across pages so that the merged result has to be sorted. fail, if set,
+// answers that page with a server error.
+func transactionPageServer(t *testing.T, total int, fail int, inFlight, maxInFlight *int32) http.HandlerFunc {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return func(w http.ResponseWriter, r *http.Request) {
+		if n := atomic.AddInt32(inFlight, 1); n > atomic.LoadInt32(maxInFlight) {
+			atomic.StoreInt32(maxInFlight, n)
+		}
+		defer atomic.AddInt32(inFlight, -1)
+		time.Sleep(5 * time.Millisecond)
+
+		pageNum, _ := strconv.Atoi(r.URL.Query().Get("pageNum"))
+		pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+		if pageNum == fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"message":"page unavailable"}`)
+			return
+		}
+
+		page := TransactionListResponse{Total: total}
+		for i := (pageNum - 1) * pageSize; i < pageNum*pageSize && i < total; i++ {
+			createdAt := base.Add(time.Duration(i*37%total) * time.Minute).Format(time.RFC3339)
+			page.Items = append(page.Items, Transaction{
+				TransactionID: StringPtr(fmt.Sprintf("t%d", i)),
+				CreatedAt:     StringPtr(createdAt),
+			})
+		}
+		page.HasMore = pageNum*pageSize < total
+		if err := json.NewEncoder(w).Encode(page); err != nil {
+			t.Errorf("encode page: %v", err)
+		}
+	}
+}
+
+func TestListAllTransactionsConcurrent(t *testing.T) {
+	tests := []struct {
+		name        string
+		total       int
+		maxItems    int
+		descending  bool
+		concurrency int
+		wantItems   int
+		wantPages   int
+	}{
+		{"single page", 40, 0, false, 4, 40, 1},
+		{"oldest first", 450, 0, false, 2, 450, 5},
+		{"newest first", 450, 0, true, 3, 450, 5},
+		{"max items", 450, 150, false, 4, 150, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var inFlight, maxInFlight int32
+			var mu sync.Mutex
+			pagesSeen := map[string]bool{}
+			handler := transactionPageServer(t, tt.total, 0, &inFlight, &maxInFlight)
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				pagesSeen[r.URL.Query().Get("pageNum")] = true
+				mu.Unlock()
+				handler(w, r)
+			})
+
+			filter := TransactionFilter{MaxItems: tt.maxItems}
+			if tt.descending {
+				filter.SortOrder = StringPtr(SortOrderDesc)
+			}
+			got, err := client.ListAllTransactionsConcurrent(context.Background(), filter, tt.concurrency)
+			if err != nil {
+				t.Fatalf("ListAllTransactionsConcurrent: %v", err)
+			}
+			if len(got) != tt.wantItems {
+				t.Errorf("got %d transactions, want %d", len(got), tt.wantItems)
+			}
+			if len(pagesSeen) != tt.wantPages {
+				t.Errorf("fetched %d pages, want %d", len(pagesSeen), tt.wantPages)
+			}
+			if int(maxInFlight) > tt.concurrency {
+				t.Errorf("%d requests in flight, want at most %d", maxInFlight, tt.concurrency)
+			}
+			for i := 1; i < len(got); i++ {
+				prev, cur := *got[i-1].CreatedAt, *got[i].CreatedAt
+				if (!tt.descending && prev > cur) || (tt.descending && prev < cur) {
+					t.Fatalf("transactions %d and %d out of order: %s, %s", i-1, i, prev, cur)
+				}
+			}
+		})
+	}
+}
+
+func TestListAllTransactionsConcurrentDropsDuplicates(t *testing.T) {
+	// A transaction created after page 1 was read pushes t99 onto page 2 as well
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		page := TransactionListResponse{Total: 150}
+		start := 0
+		if r.URL.Query().Get("pageNum") == "2" {
+			start = 99
+		}
+		for i := start; i < start+100 && i < 150; i++ {
+			page.Items = append(page.Items, Transaction{TransactionID: StringPtr(fmt.Sprintf("t%d", i))})
+		}
+		json.NewEncoder(w).Encode(page)
+	})
+
+	got, err := client.ListAllTransactionsConcurrent(context.Background(), TransactionFilter{}, 2)
+	if err != nil {
+		t.Fatalf("ListAllTransactionsConcurrent: %v", err)
+	}
+	if len(got) != 150 {
+		t.Errorf("got %d transactions, want 150 with the duplicate dropped", len(got))
+	}
+}
+
+func TestListAllTransactionsConcurrentPageError(t *testing.T) {
+	var inFlight, maxInFlight int32
+	client := newTestClient(t, transactionPageServer(t, 450, 3, &inFlight, &maxInFlight))
+
+	got, err := client.ListAllTransactionsConcurrent(context.Background(), TransactionFilter{}, 4)
+	var serverErr *BagelPayServerError
+	if !errors.As(err, &serverErr) {
+		t.Fatalf("error = %v (%T), want *BagelPayServerError", err, err)
+	}
+	if got != nil {
+		t.Errorf("got %d transactions alongside the error", len(got))
+	}
+}
+
+func TestListAllTransactionsConcurrentRejectsZeroConcurrency(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request sent for invalid concurrency")
+	})
+	if _, err := client.ListAllTransactionsConcurrent(context.Background(), TransactionFilter{}, 0); !IsValidationError(err) {
+		t.Errorf("error = %v, want a validation error", err)
+	}
+}
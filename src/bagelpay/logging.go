@@ -0,0 +1,88 @@
+package bagelpay
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// logRequest records an outgoing request at debug level
+func (c *BagelPayClient) logRequest(ctx context.Context, req *http.Request, requestID string) {
+	if c.logger == nil {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.String()),
+	}
+	if requestID != "" {
+		attrs = append(attrs, slog.String("request_id", requestID))
+	}
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "bagelpay request", attrs...)
+}
+
+// logResponse records the outcome of a request, at warn level for failures
+func (c *BagelPayClient) logResponse(ctx context.Context, req *http.Request, resp *http.Response, err error, latency time.Duration) {
+	if c.logger == nil {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.String()),
+		slog.Duration("latency", latency),
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error_type", "network"), slog.String("error", err.Error()))
+		c.logger.LogAttrs(ctx, slog.LevelWarn, "bagelpay request failed", attrs...)
+		return
+	}
+
+	attrs = append(attrs, slog.Int("status_code", resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		attrs = append(attrs, slog.String("error_type", errorTypeForStatus(resp.StatusCode)))
+		c.logger.LogAttrs(ctx, slog.LevelWarn, "bagelpay response", attrs...)
+		return
+	}
+	c.logger.LogAttrs(ctx, slog.LevelInfo, "bagelpay response", attrs...)
+}
+
+// logRetry records a rate-limit retry
+func (c *BagelPayClient) logRetry(ctx context.Context, req *http.Request, retry int, wait time.Duration) {
+	if c.logger == nil {
+		return
+	}
+	c.logger.LogAttrs(ctx, slog.LevelWarn, "bagelpay rate limited, retrying",
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.String()),
+		slog.Int("retry", retry),
+		slog.Duration("sleep", wait),
+	)
+}
+
+// errorTypeForStatus names the SDK error type returned for an HTTP status code
+func errorTypeForStatus(statusCode int) string {
+	switch {
+	case statusCode == http.StatusUnauthorized:
+		return "authentication"
+	case statusCode == http.StatusBadRequest || statusCode == http.StatusConflict || statusCode == http.StatusUnprocessableEntity:
+		return "validation"
+	case statusCode == http.StatusNotFound:
+		return "not_found"
+	case statusCode == http.StatusTooManyRequests:
+		return "rate_limit"
+	case statusCode >= 500:
+		return "server"
+	default:
+		return "api"
+	}
+}
+
+// requestIDOf returns the caller-supplied request ID carried by a request body, if any
+func requestIDOf(data interface{}) string {
+	if request, ok := data.(CheckoutRequest); ok && request.RequestID != nil {
+		return *request.RequestID
+	}
+	return ""
+}
@@ -0,0 +1,158 @@
+package bagelpay
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RoundTripperFunc adapts an ordinary function to http.RoundTripper
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req)
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// retryAttemptKey is the context key for the retry attempt of an outgoing request
+type retryAttemptKey struct{}
+
+// RetryAttempt reports which retry an outgoing request is: 0 for the first attempt,
+// 1 for the first retry, and so on. Middlewares can read it from req.Context().
+func RetryAttempt(ctx context.Context) int {
+	attempt, _ := ctx.Value(retryAttemptKey{}).(int)
+	return attempt
+}
+
+// chainMiddlewares wraps base with the middlewares; the first one is outermost
+// and therefore sees each request first
+func chainMiddlewares(base http.RoundTripper, middlewares []func(http.RoundTripper) http.RoundTripper) http.RoundTripper {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		base = middlewares[i](base)
+	}
+	return base
+}
+
+// LoggingMiddleware logs every HTTP exchange made by the client at debug level
+func LoggingMiddleware(logger *slog.Logger) func(http.RoundTripper) http.RoundTripper {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				logger.DebugContext(req.Context(), "bagelpay http exchange failed",
+					"method", req.Method,
+					"url", req.URL.String(),
+					"latency", time.Since(start),
+					"error", err,
+				)
+				return nil, err
+			}
+			logger.DebugContext(req.Context(), "bagelpay http exchange",
+				"method", req.Method,
+				"url", req.URL.String(),
+				"status_code", resp.StatusCode,
+				"latency", time.Since(start),
+			)
+			return resp, nil
+		})
+	}
+}
+
+// debugMiddleware dumps every HTTP exchange, bodies included, to logger. It is
+// installed by ClientConfig.Debug and redacts the API key to its last 4 characters.
+// At most maxBodyBytes of a response body are buffered for the log; the rest is left
+// unread so that handleResponse still rejects oversized bodies.
+func debugMiddleware(logger *log.Logger, maxBodyBytes int64) func(http.RoundTripper) http.RoundTripper {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			reqBody, err := peekRequestBody(req)
+			if err != nil {
+				return nil, err
+			}
+			logger.Printf("bagelpay: --> %s %s\n%s%s", req.Method, req.URL.String(), formatDebugHeaders(req.Header), reqBody)
+
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			latency := time.Since(start)
+			if err != nil {
+				logger.Printf("bagelpay: <-- %s %s failed after %s: %v", req.Method, req.URL.String(), latency, err)
+				return nil, err
+			}
+
+			respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+			if err != nil {
+				resp.Body.Close()
+				return nil, err
+			}
+			resp.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(respBody), resp.Body), resp.Body}
+			truncated := ""
+			if int64(len(respBody)) == maxBodyBytes {
+				truncated = fmt.Sprintf("\n[only the first %d bytes are logged]", maxBodyBytes)
+			}
+			logger.Printf("bagelpay: <-- %d %s %s (%s)\n%s%s", resp.StatusCode, req.Method, req.URL.String(), latency, respBody, truncated)
+			return resp, nil
+		})
+	}
+}
+
+// peekRequestBody returns the request body while leaving it readable for the transport
+func peekRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return io.ReadAll(body)
+	}
+
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// formatDebugHeaders renders headers one per line in a stable order with the API key redacted
+func formatDebugHeaders(header http.Header) string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		for _, value := range header[name] {
+			if strings.EqualFold(name, "x-api-key") {
+				value = redactAPIKey(value)
+			}
+			b.WriteString("  " + name + ": " + value + "\n")
+		}
+	}
+	return b.String()
+}
+
+// redactAPIKey masks all but the last 4 characters of an API key
+func redactAPIKey(key string) string {
+	if len(key) <= 4 {
+		return "****"
+	}
+	return "****" + key[len(key)-4:]
+}
@@ -0,0 +1,139 @@
+package bagelpay
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// stubTransport answers every request with status and body
+func stubTransport(status int, body string) http.RoundTripper {
+	return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+}
+
+func TestRedactAPIKey(t *testing.T) {
+	tests := map[string]string{
+		"bagel_test_abcdef1234": "****1234",
+		"12345":                 "****2345",
+		"1234":                  "****",
+		"":                      "****",
+	}
+	for key, want := range tests {
+		if got := redactAPIKey(key); got != want {
+			t.Errorf("redactAPIKey(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestDebugMiddlewareRedactsAPIKey(t *testing.T) {
+	var logs bytes.Buffer
+	transport := debugMiddleware(log.New(&logs, "", 0), 1024)(stubTransport(http.StatusOK, `{"data":{}}`))
+
+	req, _ := http.NewRequest("POST", "https://test.bagelpay.io/api/products/create", strings.NewReader(`{"name":"Bagel"}`))
+	req.Header.Set("x-api-key", "bagel_test_abcdef1234")
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	resp.Body.Close()
+
+	out := logs.String()
+	if strings.Contains(out, "bagel_test_abcdef") {
+		t.Errorf("log leaks the API key:\n%s", out)
+	}
+	for _, want := range []string{"X-Api-Key: ****1234", `{"name":"Bagel"}`, `{"data":{}}`, "<-- 200"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log is missing %q:\n%s", want, out)
+		}
+	}
+
+	// The transport must still receive the request body
+	if body, _ := io.ReadAll(req.Body); string(body) != `{"name":"Bagel"}` {
+		t.Errorf("request body after logging = %q", body)
+	}
+}
+
+func TestDebugMiddlewareLimitsBufferedBody(t *testing.T) {
+	var logs bytes.Buffer
+	body := strings.Repeat("x", 100)
+	transport := debugMiddleware(log.New(&logs, "", 0), 10)(stubTransport(http.StatusOK, body))
+
+	req, _ := http.NewRequest("GET", "https://test.bagelpay.io/api/store", nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if strings.Contains(logs.String(), strings.Repeat("x", 11)) {
+		t.Errorf("logged more than 10 bytes of the body:\n%s", logs.String())
+	}
+	// The caller still sees the whole body so that the size limit is enforced downstream
+	if got, _ := io.ReadAll(resp.Body); string(got) != body {
+		t.Errorf("response body = %d bytes, want %d", len(got), len(body))
+	}
+}
+
+// recordingMiddleware appends name to order before and after passing the request on
+func recordingMiddleware(name string, order *[]string) func(http.RoundTripper) http.RoundTripper {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			*order = append(*order, name+" in")
+			resp, err := next.RoundTrip(req)
+			*order = append(*order, name+" out")
+			return resp, err
+		})
+	}
+}
+
+func TestMiddlewaresRunInOrder(t *testing.T) {
+	var order []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		order = append(order, "server")
+		w.Write([]byte(`{"data":{}}`))
+	}, func(c *ClientConfig) {
+		c.Middlewares = []func(http.RoundTripper) http.RoundTripper{
+			recordingMiddleware("first", &order),
+			recordingMiddleware("second", &order),
+		}
+	})
+
+	if _, err := client.GetStoreInfo(context.Background()); err != nil {
+		t.Fatalf("GetStoreInfo: %v", err)
+	}
+	want := []string{"first in", "second in", "server", "second out", "first out"}
+	if strings.Join(order, ", ") != strings.Join(want, ", ") {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	transport := LoggingMiddleware(logger)(stubTransport(http.StatusCreated, `{}`))
+
+	req, _ := http.NewRequest("POST", "https://test.bagelpay.io/api/products/create", nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	resp.Body.Close()
+
+	for _, want := range []string{"method=POST", "url=https://test.bagelpay.io/api/products/create", "status_code=201"} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("log is missing %q:\n%s", want, logs.String())
+		}
+	}
+}
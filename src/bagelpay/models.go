@@ -3,95 +3,451 @@ package bagelpay
 
 import (
 	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
 )
 
-// Customer represents customer data for checkout session
+// Customer represents customer data for checkout session. Fields other than
+// Email pre-fill the checkout form when set.
 type Customer struct {
-	Email string `json:"email"`
+	Email   string           `json:"email"`
+	Name    *string          `json:"name,omitempty"`
+	Phone   *string          `json:"phone,omitempty"`
+	Country *string          `json:"country,omitempty"`
+	Address *CustomerAddress `json:"address,omitempty"`
 }
 
 // CheckoutRequest represents the request model for creating a checkout session
 type CheckoutRequest struct {
-	ProductID  string                 `json:"product_id"`
-	Customer   *Customer              `json:"customer,omitempty"`
-	RequestID  *string                `json:"request_id,omitempty"`
-	Units      *string                `json:"units,omitempty"`
-	SuccessURL *string                `json:"success_url,omitempty"`
-	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	ProductID             string                 `json:"product_id"`
+	Customer              *Customer              `json:"customer,omitempty"`
+	RequestID             *string                `json:"request_id,omitempty"`
+	Units                 *string                `json:"units,omitempty"`
+	SuccessURL            *string                `json:"success_url,omitempty"`
+	CancelURL             *string                `json:"cancel_url,omitempty"`
+	CouponCode            *string                `json:"coupon_code,omitempty"`
+	AllowedPaymentMethods []string               `json:"allowed_payment_methods,omitempty"`
+	CustomerIPAddress     *string                `json:"customer_ip_address,omitempty"`
+	CollectShipping       *bool                  `json:"collect_shipping,omitempty"`
+	ShippingCountries     []string               `json:"shipping_countries,omitempty"`
+	CollectPhone          *bool                  `json:"collect_phone,omitempty"`
+	Locale                *string                `json:"locale,omitempty"`
+	ExpiresInMinutes      *int                   `json:"expires_in_minutes,omitempty"`
+	PaymentMethodToken    *string                `json:"payment_method_token,omitempty"`
+	Metadata              map[string]interface{} `json:"metadata,omitempty"`
+	IdempotencyKey        *string                `json:"-"`
+}
+
+// CartItem represents a single product and quantity in a cart checkout
+type CartItem struct {
+	ProductID string `json:"product_id"`
+	Quantity  int    `json:"quantity"`
+}
+
+// CartCheckoutRequest represents the request model for a checkout session with multiple products
+type CartCheckoutRequest struct {
+	Items          []CartItem             `json:"items"`
+	Customer       *Customer              `json:"customer,omitempty"`
+	SuccessURL     *string                `json:"success_url,omitempty"`
+	CancelURL      *string                `json:"cancel_url,omitempty"`
+	Metadata       map[string]interface{} `json:"metadata,omitempty"`
+	IdempotencyKey *string                `json:"-"`
+}
+
+// PaymentStatus is the status of a checkout payment
+type PaymentStatus string
+
+// Payment statuses
+const (
+	PaymentStatusPending   PaymentStatus = "pending"
+	PaymentStatusCompleted PaymentStatus = "completed"
+	PaymentStatusFailed    PaymentStatus = "failed"
+	PaymentStatusRefunded  PaymentStatus = "refunded"
+)
+
+// IsTerminal reports whether the payment has reached a final status
+func (s PaymentStatus) IsTerminal() bool {
+	return s == PaymentStatusCompleted || s == PaymentStatusFailed || s == PaymentStatusRefunded
 }
 
 // CheckoutResponse represents the response model for checkout session
 type CheckoutResponse struct {
-	Object      *string                `json:"object,omitempty"`
-	Units       *int                   `json:"units,omitempty"`
-	Metadata    map[string]interface{} `json:"metadata,omitempty"`
-	Status      *string                `json:"status,omitempty"`
-	Mode        *string                `json:"mode,omitempty"`
-	PaymentID   *string                `json:"payment_id,omitempty"`
-	ProductID   *string                `json:"product_id,omitempty"`
-	RequestID   *string                `json:"request_id,omitempty"`
-	SuccessURL  *string                `json:"success_url,omitempty"`
-	CheckoutURL *string                `json:"checkout_url,omitempty"`
-	CreatedAt   *string                `json:"created_at,omitempty"`
-	UpdatedAt   *string                `json:"updated_at,omitempty"`
-	ExpiresOn   *string                `json:"expires_on,omitempty"`
+	Object          *string                `json:"object,omitempty"`
+	Units           *int                   `json:"units,omitempty"`
+	Metadata        map[string]interface{} `json:"metadata,omitempty"`
+	Status          *PaymentStatus         `json:"status,omitempty"`
+	Mode            *string                `json:"mode,omitempty"`
+	PaymentID       *string                `json:"payment_id,omitempty"`
+	ProductID       *string                `json:"product_id,omitempty"`
+	RequestID       *string                `json:"request_id,omitempty"`
+	SuccessURL      *string                `json:"success_url,omitempty"`
+	CancelURL       *string                `json:"cancel_url,omitempty"`
+	CouponCode      *string                `json:"coupon_code,omitempty"`
+	DiscountAmount  *float64               `json:"discount_amount,omitempty"`
+	DetectedCountry *string                `json:"detected_country,omitempty"`
+	EstimatedTax    *float64               `json:"estimated_tax,omitempty"`
+	Customer        *Customer              `json:"customer,omitempty"`
+	ShippingAddress *CustomerAddress       `json:"shipping_address,omitempty"`
+	CheckoutURL     *string                `json:"checkout_url,omitempty"`
+	CreatedAt       *string                `json:"created_at,omitempty"`
+	UpdatedAt       *string                `json:"updated_at,omitempty"`
+	ExpiresOn       *string                `json:"expires_on,omitempty"`
+}
+
+// ExpiresIn returns how long the checkout link stays valid, parsed from ExpiresOn.
+// The duration is negative once the checkout has expired.
+func (c CheckoutResponse) ExpiresIn() (time.Duration, error) {
+	expiresOn, err := parseTimestamp(c.ExpiresOn)
+	if err != nil {
+		return 0, err
+	}
+	return time.Until(expiresOn), nil
+}
+
+// IsExpired reports whether the checkout link has expired. Checkouts without a
+// parseable ExpiresOn are treated as not expired.
+func (c CheckoutResponse) IsExpired() bool {
+	expiresIn, err := c.ExpiresIn()
+	return err == nil && expiresIn <= 0
+}
+
+// CheckoutFilter represents optional filters for listing checkout sessions.
+// The zero value applies no filtering.
+type CheckoutFilter struct {
+	Status        *string
+	ProductID     *string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+// apply adds the non-nil filter fields to the query parameters
+func (f CheckoutFilter) apply(params map[string]string) {
+	if f.Status != nil {
+		params["status"] = *f.Status
+	}
+	if f.ProductID != nil {
+		params["productId"] = *f.ProductID
+	}
+	if f.CreatedAfter != nil {
+		params["createdAfter"] = f.CreatedAfter.UTC().Format(time.RFC3339)
+	}
+	if f.CreatedBefore != nil {
+		params["createdBefore"] = f.CreatedBefore.UTC().Format(time.RFC3339)
+	}
+}
+
+// CheckoutListResponse represents the checkout session list response
+type CheckoutListResponse struct {
+	Total         int                `json:"total"`
+	Items         []CheckoutResponse `json:"items"`
+	HasMore       bool               `json:"has_more"`
+	NextPageToken *string            `json:"next_page_token,omitempty"`
+	Code          int                `json:"code"`
+	Msg           string             `json:"msg"`
+}
+
+// Coupon discount types
+const (
+	DiscountTypePercent = "percent"
+	DiscountTypeFixed   = "fixed"
+)
+
+// CouponRequest represents the request model for creating a coupon
+type CouponRequest struct {
+	Code           string     `json:"code"`
+	DiscountType   string     `json:"discount_type"`
+	DiscountValue  float64    `json:"discount_value"`
+	MaxUses        *int       `json:"max_uses,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	IdempotencyKey *string    `json:"-"`
+}
+
+// Coupon represents a coupon model
+type Coupon struct {
+	Code          *string  `json:"code,omitempty"`
+	DiscountType  *string  `json:"discount_type,omitempty"`
+	DiscountValue *float64 `json:"discount_value,omitempty"`
+	MaxUses       *int     `json:"max_uses,omitempty"`
+	UsedCount     *int     `json:"used_count,omitempty"`
+	ExpiresAt     *string  `json:"expires_at,omitempty"`
+}
+
+// CouponListResponse represents the coupon list response
+type CouponListResponse struct {
+	Total         int      `json:"total"`
+	Items         []Coupon `json:"items"`
+	HasMore       bool     `json:"has_more"`
+	NextPageToken *string  `json:"next_page_token,omitempty"`
+	Code          int      `json:"code"`
+	Msg           string   `json:"msg"`
+}
+
+// PaymentLinkOptions configures a payment link. The zero value creates an unlimited,
+// non-expiring link with a generated URL.
+type PaymentLinkOptions struct {
+	// Slug is the readable last segment of the link URL, e.g. "spring-sale"
+	Slug           *string    `json:"slug,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	MaxUses        *int       `json:"max_uses,omitempty"`
+	Customer       *Customer  `json:"customer,omitempty"`
+	IdempotencyKey *string    `json:"-"`
+}
+
+// paymentLinkRequest is the body of a payment link creation; the options are
+// flattened next to the product ID
+type paymentLinkRequest struct {
+	ProductID string `json:"product_id"`
+	PaymentLinkOptions
+}
+
+// PaymentLink represents a reusable URL that starts a new checkout for a product on every visit
+type PaymentLink struct {
+	ID        *string `json:"id,omitempty"`
+	URL       *string `json:"url,omitempty"`
+	ShortURL  *string `json:"short_url,omitempty"`
+	ProductID *string `json:"product_id,omitempty"`
+	ExpiresAt *string `json:"expires_at,omitempty"`
+	MaxUses   *int    `json:"max_uses,omitempty"`
+	UsedCount *int    `json:"used_count,omitempty"`
+}
+
+// PaymentLinkListResponse represents the payment link list response
+type PaymentLinkListResponse struct {
+	Total         int           `json:"total"`
+	Items         []PaymentLink `json:"items"`
+	HasMore       bool          `json:"has_more"`
+	NextPageToken *string       `json:"next_page_token,omitempty"`
+	Code          int           `json:"code"`
+	Msg           string        `json:"msg"`
+}
+
+// Billing types
+const (
+	BillingTypeSinglePayment = "single_payment"
+	BillingTypeSubscription  = "subscription"
+)
+
+// Tax categories
+const (
+	TaxCategoryDigitalProducts = "digital_products"
+	TaxCategorySaasServices    = "saas_services"
+	TaxCategoryEbooks          = "ebooks"
+)
+
+// Recurring intervals for subscription products
+const (
+	IntervalDaily   = "daily"
+	IntervalWeekly  = "weekly"
+	IntervalMonthly = "monthly"
+	Interval3Months = "3months"
+	Interval6Months = "6months"
+	IntervalYearly  = "yearly"
+)
+
+// ValidBillingType reports whether s is a billing type accepted by the API
+func ValidBillingType(s string) bool {
+	return s == BillingTypeSinglePayment || s == BillingTypeSubscription
 }
 
 // CreateProductRequest represents the request model for creating a product
 type CreateProductRequest struct {
-	Name              string  `json:"name"`
-	Description       string  `json:"description"`
-	Price             float64 `json:"price"`
-	Currency          string  `json:"currency"`
-	BillingType       string  `json:"billing_type"`
-	TaxInclusive      bool    `json:"tax_inclusive"`
-	TaxCategory       string  `json:"tax_category"`
-	RecurringInterval string  `json:"recurring_interval"`
-	TrialDays         int     `json:"trial_days"`
+	Name              string                 `json:"name"`
+	Description       string                 `json:"description"`
+	Price             float64                `json:"price"`
+	Currency          string                 `json:"currency"`
+	BillingType       string                 `json:"billing_type"`
+	TaxInclusive      bool                   `json:"tax_inclusive"`
+	TaxCategory       string                 `json:"tax_category"`
+	RecurringInterval string                 `json:"recurring_interval"`
+	TrialDays         int                    `json:"trial_days"`
+	ImageURL          *string                `json:"image_url,omitempty"`
+	Category          *string                `json:"category,omitempty"`
+	Tags              []string               `json:"tags,omitempty"`
+	Metadata          map[string]interface{} `json:"metadata,omitempty"`
+	IdempotencyKey    *string                `json:"-"`
 }
 
 // Product represents a product model
 type Product struct {
-	Name              *string  `json:"name,omitempty"`
-	Description       *string  `json:"description,omitempty"`
-	Price             *float64 `json:"price,omitempty"`
-	Currency          *string  `json:"currency,omitempty"`
-	Object            *string  `json:"object,omitempty"`
-	Mode              *string  `json:"mode,omitempty"`
-	ProductID         *string  `json:"product_id,omitempty"`
-	StoreID           *string  `json:"store_id,omitempty"`
-	ProductURL        *string  `json:"product_url,omitempty"`
-	BillingType       *string  `json:"billing_type,omitempty"`
-	BillingPeriod     *string  `json:"billing_period,omitempty"`
-	TaxCategory       *string  `json:"tax_category,omitempty"`
-	TaxInclusive      *bool    `json:"tax_inclusive,omitempty"`
-	IsArchive         *bool    `json:"is_archive,omitempty"`
-	CreatedAt         *string  `json:"created_at,omitempty"`
-	UpdatedAt         *string  `json:"updated_at,omitempty"`
-	TrialDays         *int     `json:"trial_days,omitempty"`
-	RecurringInterval *string  `json:"recurring_interval,omitempty"`
+	Name              *string                `json:"name,omitempty"`
+	Description       *string                `json:"description,omitempty"`
+	Price             *float64               `json:"price,omitempty"`
+	Currency          *string                `json:"currency,omitempty"`
+	Object            *string                `json:"object,omitempty"`
+	Mode              *string                `json:"mode,omitempty"`
+	ProductID         *string                `json:"product_id,omitempty"`
+	StoreID           *string                `json:"store_id,omitempty"`
+	ProductURL        *string                `json:"product_url,omitempty"`
+	BillingType       *string                `json:"billing_type,omitempty"`
+	BillingPeriod     *string                `json:"billing_period,omitempty"`
+	TaxCategory       *string                `json:"tax_category,omitempty"`
+	TaxInclusive      *bool                  `json:"tax_inclusive,omitempty"`
+	IsArchive         *bool                  `json:"is_archive,omitempty"`
+	CreatedAt         *string                `json:"created_at,omitempty"`
+	UpdatedAt         *string                `json:"updated_at,omitempty"`
+	TrialDays         *int                   `json:"trial_days,omitempty"`
+	RecurringInterval *string                `json:"recurring_interval,omitempty"`
+	ImageURL          *string                `json:"image_url,omitempty"`
+	Category          *string                `json:"category,omitempty"`
+	Tags              []string               `json:"tags,omitempty"`
+	Metadata          map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// IsSubscription reports whether the product bills on a recurring interval
+func (p Product) IsSubscription() bool {
+	return p.BillingType != nil && *p.BillingType == BillingTypeSubscription
+}
+
+// IsSinglePayment reports whether the product is paid for once
+func (p Product) IsSinglePayment() bool {
+	return p.BillingType != nil && *p.BillingType == BillingTypeSinglePayment
+}
+
+// Sort fields for list filters; each filter documents the fields it accepts
+const (
+	SortByCreatedAt  = "created_at"
+	SortByName       = "name"
+	SortByPrice      = "price"
+	SortByAmount     = "amount"
+	SortByEmail      = "email"
+	SortByTotalSpend = "total_spend"
+)
+
+// Sort orders for list filters
+const (
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+// ProductFilter represents optional filters for listing products.
+// The zero value applies no filtering. MaxItems caps the number of products
+// returned by ListAllProducts and is not sent to the API (zero means no cap).
+// SortBy accepts SortByPrice, SortByName or SortByCreatedAt; SortOrder SortOrderAsc or SortOrderDesc.
+// Tags matches products carrying all of the given tags.
+// PageToken resumes listing from the NextPageToken of an earlier page; iterators and
+// the ListAll helpers manage it themselves and always start from the first page.
+type ProductFilter struct {
+	BillingType *string
+	IsArchived  *bool
+	TaxCategory *string
+	MinPrice    *float64
+	MaxPrice    *float64
+	Currency    *string
+	Category    *string
+	Tags        []string
+	SortBy      *string
+	SortOrder   *string
+	PageToken   *string
+	MaxItems    int
+}
+
+// apply adds the non-nil filter fields to the query parameters
+func (f ProductFilter) apply(params map[string]string) {
+	if f.BillingType != nil {
+		params["billingType"] = *f.BillingType
+	}
+	if f.IsArchived != nil {
+		params["isArchived"] = strconv.FormatBool(*f.IsArchived)
+	}
+	if f.TaxCategory != nil {
+		params["taxCategory"] = *f.TaxCategory
+	}
+	if f.MinPrice != nil {
+		params["minPrice"] = strconv.FormatFloat(*f.MinPrice, 'f', -1, 64)
+	}
+	if f.MaxPrice != nil {
+		params["maxPrice"] = strconv.FormatFloat(*f.MaxPrice, 'f', -1, 64)
+	}
+	if f.Currency != nil {
+		params["currency"] = *f.Currency
+	}
+	if f.Category != nil {
+		params["category"] = *f.Category
+	}
+	if len(f.Tags) > 0 {
+		params["tags"] = strings.Join(f.Tags, ",")
+	}
+	if f.SortBy != nil {
+		params["sortBy"] = *f.SortBy
+	}
+	if f.SortOrder != nil {
+		params["sortOrder"] = *f.SortOrder
+	}
+	if f.PageToken != nil {
+		params["pageToken"] = *f.PageToken
+	}
 }
 
 // ProductListResponse represents the product list response
 type ProductListResponse struct {
-	Total int       `json:"total"`
-	Items []Product `json:"items"`
-	Code  int       `json:"code"`
-	Msg   string    `json:"msg"`
+	Total         int       `json:"total"`
+	Items         []Product `json:"items"`
+	HasMore       bool      `json:"has_more"`
+	NextPageToken *string   `json:"next_page_token,omitempty"`
+	Code          int       `json:"code"`
+	Msg           string    `json:"msg"`
 }
 
 // UpdateProductRequest represents the request model for updating a product
 type UpdateProductRequest struct {
-	ProductID         string  `json:"product_id"`
-	Name              string  `json:"name"`
-	Description       string  `json:"description"`
-	Price             float64 `json:"price"`
-	Currency          string  `json:"currency"`
-	BillingType       string  `json:"billing_type"`
-	TaxInclusive      bool    `json:"tax_inclusive"`
-	TaxCategory       string  `json:"tax_category"`
-	RecurringInterval string  `json:"recurring_interval"`
-	TrialDays         int     `json:"trial_days"`
+	ProductID         string                 `json:"product_id"`
+	Name              string                 `json:"name"`
+	Description       string                 `json:"description"`
+	Price             float64                `json:"price"`
+	Currency          string                 `json:"currency"`
+	BillingType       string                 `json:"billing_type"`
+	TaxInclusive      bool                   `json:"tax_inclusive"`
+	TaxCategory       string                 `json:"tax_category"`
+	RecurringInterval string                 `json:"recurring_interval"`
+	TrialDays         int                    `json:"trial_days"`
+	ImageURL          *string                `json:"image_url,omitempty"`
+	Category          *string                `json:"category,omitempty"`
+	Tags              []string               `json:"tags,omitempty"`
+	Metadata          map[string]interface{} `json:"metadata,omitempty"`
+	IdempotencyKey    *string                `json:"-"`
+}
+
+// PatchProductRequest represents the request model for a partial product update.
+// Only non-nil fields are sent; the rest of the product is left unchanged. Tags and
+// Metadata replace the product's values; point them at an empty value to clear them.
+type PatchProductRequest struct {
+	ProductID         string                  `json:"product_id"`
+	Name              *string                 `json:"name,omitempty"`
+	Description       *string                 `json:"description,omitempty"`
+	Price             *float64                `json:"price,omitempty"`
+	Currency          *string                 `json:"currency,omitempty"`
+	BillingType       *string                 `json:"billing_type,omitempty"`
+	TaxInclusive      *bool                   `json:"tax_inclusive,omitempty"`
+	TaxCategory       *string                 `json:"tax_category,omitempty"`
+	RecurringInterval *string                 `json:"recurring_interval,omitempty"`
+	TrialDays         *int                    `json:"trial_days,omitempty"`
+	ImageURL          *string                 `json:"image_url,omitempty"`
+	Category          *string                 `json:"category,omitempty"`
+	Tags              *[]string               `json:"tags,omitempty"`
+	Metadata          *map[string]interface{} `json:"metadata,omitempty"`
+	IdempotencyKey    *string                 `json:"-"`
+}
+
+// ProductOverrides lists the fields to change when duplicating a product. Nil fields
+// are copied from the source product.
+type ProductOverrides struct {
+	Name              *string                 `json:"name,omitempty"`
+	Description       *string                 `json:"description,omitempty"`
+	Price             *float64                `json:"price,omitempty"`
+	Currency          *string                 `json:"currency,omitempty"`
+	BillingType       *string                 `json:"billing_type,omitempty"`
+	TaxInclusive      *bool                   `json:"tax_inclusive,omitempty"`
+	TaxCategory       *string                 `json:"tax_category,omitempty"`
+	RecurringInterval *string                 `json:"recurring_interval,omitempty"`
+	TrialDays         *int                    `json:"trial_days,omitempty"`
+	ImageURL          *string                 `json:"image_url,omitempty"`
+	Category          *string                 `json:"category,omitempty"`
+	Tags              *[]string               `json:"tags,omitempty"`
+	Metadata          *map[string]interface{} `json:"metadata,omitempty"`
+	IdempotencyKey    *string                 `json:"-"`
 }
 
 // TransactionCustomer represents customer data in transaction
@@ -100,35 +456,319 @@ type TransactionCustomer struct {
 	Email *string `json:"email,omitempty"`
 }
 
+// TransactionLineItem represents a single line item in a transaction
+type TransactionLineItem struct {
+	ProductID   *string  `json:"product_id,omitempty"`
+	ProductName *string  `json:"product_name,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	Units       *int     `json:"units,omitempty"`
+	UnitPrice   *float64 `json:"unit_price,omitempty"`
+	Amount      *float64 `json:"amount,omitempty"`
+	TaxAmount   *float64 `json:"tax_amount,omitempty"`
+	Currency    *string  `json:"currency,omitempty"`
+}
+
+// TransactionType is the kind of money movement a transaction records
+type TransactionType string
+
+// Transaction types
+const (
+	TransactionTypePayment TransactionType = "payment"
+	TransactionTypeRefund  TransactionType = "refund"
+)
+
 // Transaction represents a transaction model
 type Transaction struct {
-	Object         *string              `json:"object,omitempty"`
-	OrderID        *string              `json:"order_id,omitempty"`
-	TransactionID  *string              `json:"transaction_id,omitempty"`
-	Amount         *float64             `json:"amount,omitempty"`
-	AmountPaid     *float64             `json:"amount_paid,omitempty"`
-	DiscountAmount *float64             `json:"discount_amount,omitempty"`
-	Currency       *string              `json:"currency,omitempty"`
-	TaxAmount      *float64             `json:"tax_amount,omitempty"`
-	TaxCountry     *string              `json:"tax_country,omitempty"`
-	RefundedAmount *float64             `json:"refunded_amount,omitempty"`
-	Type           *string              `json:"type,omitempty"`
-	Customer       *TransactionCustomer `json:"customer,omitempty"`
-	CreatedAt      *string              `json:"created_at,omitempty"`
-	UpdatedAt      *string              `json:"updated_at,omitempty"`
-	Remark         *string              `json:"remark,omitempty"`
-	Mode           *string              `json:"mode,omitempty"`
-	Fees           *float64             `json:"fees,omitempty"`
-	Tax            *float64             `json:"tax,omitempty"`
-	Net            *float64             `json:"net,omitempty"`
+	Object         *string               `json:"object,omitempty"`
+	OrderID        *string               `json:"order_id,omitempty"`
+	TransactionID  *string               `json:"transaction_id,omitempty"`
+	Amount         *float64              `json:"amount,omitempty"`
+	AmountPaid     *float64              `json:"amount_paid,omitempty"`
+	DiscountAmount *float64              `json:"discount_amount,omitempty"`
+	Currency       *string               `json:"currency,omitempty"`
+	TaxAmount      *float64              `json:"tax_amount,omitempty"`
+	TaxCountry     *string               `json:"tax_country,omitempty"`
+	RefundedAmount *float64              `json:"refunded_amount,omitempty"`
+	Type           *TransactionType      `json:"type,omitempty"`
+	Customer       *TransactionCustomer  `json:"customer,omitempty"`
+	CreatedAt      *string               `json:"created_at,omitempty"`
+	UpdatedAt      *string               `json:"updated_at,omitempty"`
+	Remark         *string               `json:"remark,omitempty"`
+	Mode           *string               `json:"mode,omitempty"`
+	Fees           *float64              `json:"fees,omitempty"`
+	Tax            *float64              `json:"tax,omitempty"`
+	Net            *float64              `json:"net,omitempty"`
+	Gateway        *string               `json:"gateway,omitempty"`
+	ExternalID     *string               `json:"external_id,omitempty"`
+	PaymentMethod  *string               `json:"payment_method,omitempty"`
+	CardBrand      *string               `json:"card_brand,omitempty"`
+	CardLast4      *string               `json:"card_last4,omitempty"`
+	CardExpMonth   *int                  `json:"card_exp_month,omitempty"`
+	CardExpYear    *int                  `json:"card_exp_year,omitempty"`
+	LineItems      []TransactionLineItem `json:"line_items,omitempty"`
+}
+
+// NetAmount returns what the merchant keeps from the transaction: AmountPaid
+// minus Fees and TaxAmount. Missing fields count as zero.
+func (t Transaction) NetAmount() float64 {
+	return derefFloat(t.AmountPaid) - derefFloat(t.Fees) - derefFloat(t.TaxAmount)
+}
+
+// EffectiveTaxRate returns TaxAmount as a fraction of AmountPaid, or 0 when
+// nothing was paid
+func (t Transaction) EffectiveTaxRate() float64 {
+	paid := derefFloat(t.AmountPaid)
+	if paid == 0 {
+		return 0
+	}
+	return derefFloat(t.TaxAmount) / paid
+}
+
+// TransactionFilter represents optional filters for listing transactions.
+// The zero value applies no filtering. MaxItems caps the number of transactions
+// returned by ListAllTransactions and is not sent to the API (zero means no cap).
+// SortBy accepts SortByAmount or SortByCreatedAt; SortOrder SortOrderAsc or SortOrderDesc.
+// PageToken resumes listing from the NextPageToken of an earlier page; iterators and
+// the ListAll helpers manage it themselves and always start from the first page.
+type TransactionFilter struct {
+	From           *time.Time
+	To             *time.Time
+	Currency       *string
+	Type           *TransactionType
+	MinAmount      *float64
+	MaxAmount      *float64
+	ProductID      *string
+	SubscriptionID *string
+	SortBy         *string
+	SortOrder      *string
+	PageToken      *string
+	MaxItems       int
+}
+
+// apply adds the non-nil filter fields to the query parameters
+func (f TransactionFilter) apply(params map[string]string) {
+	if f.From != nil {
+		params["from"] = f.From.UTC().Format(time.RFC3339)
+	}
+	if f.To != nil {
+		params["to"] = f.To.UTC().Format(time.RFC3339)
+	}
+	if f.Currency != nil {
+		params["currency"] = *f.Currency
+	}
+	if f.Type != nil {
+		params["type"] = string(*f.Type)
+	}
+	if f.MinAmount != nil {
+		params["minAmount"] = strconv.FormatFloat(*f.MinAmount, 'f', -1, 64)
+	}
+	if f.MaxAmount != nil {
+		params["maxAmount"] = strconv.FormatFloat(*f.MaxAmount, 'f', -1, 64)
+	}
+	if f.ProductID != nil {
+		params["productId"] = *f.ProductID
+	}
+	if f.SubscriptionID != nil {
+		params["subscriptionId"] = *f.SubscriptionID
+	}
+	if f.SortBy != nil {
+		params["sortBy"] = *f.SortBy
+	}
+	if f.SortOrder != nil {
+		params["sortOrder"] = *f.SortOrder
+	}
+	if f.PageToken != nil {
+		params["pageToken"] = *f.PageToken
+	}
 }
 
 // TransactionListResponse represents the transaction list response
 type TransactionListResponse struct {
-	Total int           `json:"total"`
-	Items []Transaction `json:"items"`
-	Code  int           `json:"code"`
-	Msg   string        `json:"msg"`
+	Total         int           `json:"total"`
+	Items         []Transaction `json:"items"`
+	HasMore       bool          `json:"has_more"`
+	NextPageToken *string       `json:"next_page_token,omitempty"`
+	Code          int           `json:"code"`
+	Msg           string        `json:"msg"`
+}
+
+// RefundRequest represents the request model for refunding a transaction
+type RefundRequest struct {
+	TransactionID  string                 `json:"transaction_id"`
+	Amount         float64                `json:"amount,omitempty"`
+	Reason         string                 `json:"reason,omitempty"`
+	Metadata       map[string]interface{} `json:"metadata,omitempty"`
+	IdempotencyKey *string                `json:"-"`
+}
+
+// Refund represents a refund model
+type Refund struct {
+	Object         *string                `json:"object,omitempty"`
+	RefundID       *string                `json:"refund_id,omitempty"`
+	TransactionID  *string                `json:"transaction_id,omitempty"`
+	Status         *string                `json:"status,omitempty"`
+	RefundedAmount *float64               `json:"refunded_amount,omitempty"`
+	Currency       *string                `json:"currency,omitempty"`
+	Reason         *string                `json:"reason,omitempty"`
+	Metadata       map[string]interface{} `json:"metadata,omitempty"`
+	Mode           *string                `json:"mode,omitempty"`
+	CreatedAt      *string                `json:"created_at,omitempty"`
+	UpdatedAt      *string                `json:"updated_at,omitempty"`
+}
+
+// RefundListResponse represents the refund list response
+type RefundListResponse struct {
+	Total         int      `json:"total"`
+	Items         []Refund `json:"items"`
+	HasMore       bool     `json:"has_more"`
+	NextPageToken *string  `json:"next_page_token,omitempty"`
+	Code          int      `json:"code"`
+	Msg           string   `json:"msg"`
+}
+
+// DisputeStatus is the status of a dispute
+type DisputeStatus string
+
+// Dispute statuses
+const (
+	DisputeStatusNeedsResponse DisputeStatus = "needs_response"
+	DisputeStatusUnderReview   DisputeStatus = "under_review"
+	DisputeStatusWon           DisputeStatus = "won"
+	DisputeStatusLost          DisputeStatus = "lost"
+)
+
+// IsOpen reports whether the dispute has not been decided yet
+func (s DisputeStatus) IsOpen() bool {
+	return s == DisputeStatusNeedsResponse || s == DisputeStatusUnderReview
+}
+
+// DisputeEvidence represents the evidence submitted to contest a dispute
+type DisputeEvidence struct {
+	ProductDescription     *string `json:"product_description,omitempty"`
+	CustomerCommunication  *string `json:"customer_communication,omitempty"`
+	RefundPolicy           *string `json:"refund_policy,omitempty"`
+	ServiceDate            *string `json:"service_date,omitempty"`
+	ShippingTrackingNumber *string `json:"shipping_tracking_number,omitempty"`
+	AdditionalInfo         *string `json:"additional_info,omitempty"`
+	IdempotencyKey         *string `json:"-"`
+}
+
+// Dispute represents a chargeback raised by a customer's bank against a transaction
+type Dispute struct {
+	DisputeID     *string          `json:"dispute_id,omitempty"`
+	TransactionID *string          `json:"transaction_id,omitempty"`
+	Amount        *float64         `json:"amount,omitempty"`
+	Currency      *string          `json:"currency,omitempty"`
+	Reason        *string          `json:"reason,omitempty"`
+	Status        *DisputeStatus   `json:"status,omitempty"`
+	Evidence      *DisputeEvidence `json:"evidence,omitempty"`
+	DueBy         *string          `json:"due_by,omitempty"`
+	CreatedAt     *string          `json:"created_at,omitempty"`
+}
+
+// DisputeFilter represents optional filters for listing disputes.
+// The zero value applies no filtering.
+type DisputeFilter struct {
+	Status        *string
+	TransactionID *string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+// apply adds the non-nil filter fields to the query parameters
+func (f DisputeFilter) apply(params map[string]string) {
+	if f.Status != nil {
+		params["status"] = *f.Status
+	}
+	if f.TransactionID != nil {
+		params["transactionId"] = *f.TransactionID
+	}
+	if f.CreatedAfter != nil {
+		params["createdAfter"] = f.CreatedAfter.UTC().Format(time.RFC3339)
+	}
+	if f.CreatedBefore != nil {
+		params["createdBefore"] = f.CreatedBefore.UTC().Format(time.RFC3339)
+	}
+}
+
+// DisputeListResponse represents the dispute list response
+type DisputeListResponse struct {
+	Total         int       `json:"total"`
+	Items         []Dispute `json:"items"`
+	HasMore       bool      `json:"has_more"`
+	NextPageToken *string   `json:"next_page_token,omitempty"`
+	Code          int       `json:"code"`
+	Msg           string    `json:"msg"`
+}
+
+// InvoiceLine represents a single line of an invoice
+type InvoiceLine struct {
+	Description *string  `json:"description,omitempty"`
+	ProductID   *string  `json:"product_id,omitempty"`
+	Quantity    *int     `json:"quantity,omitempty"`
+	Amount      *float64 `json:"amount,omitempty"`
+	Currency    *string  `json:"currency,omitempty"`
+	PeriodStart *string  `json:"period_start,omitempty"`
+	PeriodEnd   *string  `json:"period_end,omitempty"`
+}
+
+// Invoice represents an invoice model
+type Invoice struct {
+	Object           *string       `json:"object,omitempty"`
+	InvoiceID        *string       `json:"invoice_id,omitempty"`
+	Status           *string       `json:"status,omitempty"`
+	AmountDue        *float64      `json:"amount_due,omitempty"`
+	AmountPaid       *float64      `json:"amount_paid,omitempty"`
+	Currency         *string       `json:"currency,omitempty"`
+	DueDate          *string       `json:"due_date,omitempty"`
+	PaidAt           *string       `json:"paid_at,omitempty"`
+	Lines            []InvoiceLine `json:"lines,omitempty"`
+	CustomerID       *int          `json:"customer_id,omitempty"`
+	SubscriptionID   *string       `json:"subscription_id,omitempty"`
+	HostedInvoiceURL *string       `json:"hosted_invoice_url,omitempty"`
+	InvoicePDF       *string       `json:"invoice_pdf,omitempty"`
+	Mode             *string       `json:"mode,omitempty"`
+	CreatedAt        *string       `json:"created_at,omitempty"`
+	UpdatedAt        *string       `json:"updated_at,omitempty"`
+}
+
+// InvoiceFilter represents optional filters for listing invoices.
+// The zero value applies no filtering.
+type InvoiceFilter struct {
+	SubscriptionID *string
+	CustomerID     *int
+	Status         *string
+	From           *time.Time
+	To             *time.Time
+}
+
+// apply adds the non-nil filter fields to the query parameters
+func (f InvoiceFilter) apply(params map[string]string) {
+	if f.SubscriptionID != nil {
+		params["subscriptionId"] = *f.SubscriptionID
+	}
+	if f.CustomerID != nil {
+		params["customerId"] = strconv.Itoa(*f.CustomerID)
+	}
+	if f.Status != nil {
+		params["status"] = *f.Status
+	}
+	if f.From != nil {
+		params["from"] = f.From.UTC().Format(time.RFC3339)
+	}
+	if f.To != nil {
+		params["to"] = f.To.UTC().Format(time.RFC3339)
+	}
+}
+
+// InvoiceListResponse represents the invoice list response
+type InvoiceListResponse struct {
+	Total         int       `json:"total"`
+	Items         []Invoice `json:"items"`
+	HasMore       bool      `json:"has_more"`
+	NextPageToken *string   `json:"next_page_token,omitempty"`
+	Code          int       `json:"code"`
+	Msg           string    `json:"msg"`
 }
 
 // SubscriptionCustomer represents customer data in subscription
@@ -137,21 +777,42 @@ type SubscriptionCustomer struct {
 	Email *string `json:"email,omitempty"`
 }
 
+// SubscriptionStatus is the status of a subscription
+type SubscriptionStatus string
+
+// Subscription statuses. The API spells the cancelled status "canceled".
+const (
+	SubscriptionStatusActive    SubscriptionStatus = "active"
+	SubscriptionStatusCancelled SubscriptionStatus = "canceled"
+	SubscriptionStatusPaused    SubscriptionStatus = "paused"
+	SubscriptionStatusTrialing  SubscriptionStatus = "trialing"
+	SubscriptionStatusPastDue   SubscriptionStatus = "past_due"
+)
+
+// IsActive reports whether the subscription currently grants access to the product
+func (s SubscriptionStatus) IsActive() bool {
+	return s == SubscriptionStatusActive || s == SubscriptionStatusTrialing
+}
+
 // Subscription represents a subscription model
 type Subscription struct {
 	Object             *string               `json:"object,omitempty"`
-	Status             *string               `json:"status,omitempty"`
+	Status             *SubscriptionStatus   `json:"status,omitempty"`
 	Remark             *string               `json:"remark,omitempty"`
 	Customer           *SubscriptionCustomer `json:"customer,omitempty"`
 	Mode               *string               `json:"mode,omitempty"`
 	Amount             *float64              `json:"amount,omitempty"`
 	Last4              *string               `json:"last4,omitempty"`
+	CardBrand          *string               `json:"card_brand,omitempty"`
+	CardExpMonth       *int                  `json:"card_exp_month,omitempty"`
+	CardExpYear        *int                  `json:"card_exp_year,omitempty"`
 	SubscriptionID     *string               `json:"subscription_id,omitempty"`
 	ProductID          *string               `json:"product_id,omitempty"`
 	StoreID            *string               `json:"store_id,omitempty"`
 	BillingPeriodStart *string               `json:"billing_period_start,omitempty"`
 	BillingPeriodEnd   *string               `json:"billing_period_end,omitempty"`
 	CancelAt           *string               `json:"cancel_at,omitempty"`
+	CancelAtPeriodEnd  *bool                 `json:"cancel_at_period_end,omitempty"`
 	TrialStart         *string               `json:"trial_start,omitempty"`
 	TrialEnd           *string               `json:"trial_end,omitempty"`
 	Units              *int                  `json:"units,omitempty"`
@@ -161,36 +822,522 @@ type Subscription struct {
 	PaymentMethod      *string               `json:"payment_method,omitempty"`
 	NextBillingAmount  *float64              `json:"next_billing_amount,omitempty"`
 	RecurringInterval  *string               `json:"recurring_interval,omitempty"`
+	PausedAt           *string               `json:"paused_at,omitempty"`
+	ResumesAt          *string               `json:"resumes_at,omitempty"`
+	CancellationReason *string               `json:"cancellation_reason,omitempty"`
+}
+
+// IsPaused reports whether the subscription is currently paused
+func (s Subscription) IsPaused() bool {
+	return s.Status != nil && *s.Status == SubscriptionStatusPaused
+}
+
+// IsInTrial reports whether the subscription is in its trial period. TrialEnd is
+// compared against the current time when present; otherwise the status decides.
+func (s Subscription) IsInTrial() bool {
+	if end, err := parseTimestamp(s.TrialEnd); err == nil {
+		return time.Now().Before(end) && !s.IsCancelled()
+	}
+	return s.Status != nil && *s.Status == SubscriptionStatusTrialing
+}
+
+// IsActive reports whether the subscription currently grants access to the
+// product: it is active or trialing and has not reached its CancelAt time
+func (s Subscription) IsActive() bool {
+	return s.Status != nil && s.Status.IsActive() && !s.IsCancelled()
+}
+
+// IsCancelled reports whether the subscription has been cancelled, either by
+// status or because its CancelAt time has passed. A subscription scheduled to
+// cancel at period end is not cancelled until then.
+func (s Subscription) IsCancelled() bool {
+	if s.Status != nil && *s.Status == SubscriptionStatusCancelled {
+		return true
+	}
+	cancelAt, err := parseTimestamp(s.CancelAt)
+	return err == nil && !time.Now().Before(cancelAt)
+}
+
+// IsPastDue reports whether the latest renewal payment failed and is being retried
+func (s Subscription) IsPastDue() bool {
+	return s.Status != nil && *s.Status == SubscriptionStatusPastDue
+}
+
+// NextBillingDate returns BillingPeriodEnd, when the subscription next renews
+func (s Subscription) NextBillingDate() (time.Time, error) {
+	return parseTimestamp(s.BillingPeriodEnd)
+}
+
+// DaysUntilBillingPeriodEnd returns the number of days, rounded up, until the
+// current billing period ends. It returns 0 when the period has ended or
+// BillingPeriodEnd is missing or malformed.
+func (s Subscription) DaysUntilBillingPeriodEnd() int {
+	end, err := s.NextBillingDate()
+	if err != nil {
+		return 0
+	}
+	remaining := time.Until(end)
+	if remaining <= 0 {
+		return 0
+	}
+	return int(math.Ceil(remaining.Hours() / 24))
+}
+
+// CancelSubscriptionOptions controls how a subscription is cancelled.
+// The zero value cancels immediately without a reason.
+type CancelSubscriptionOptions struct {
+	// AtPeriodEnd keeps the subscription active until the end of the current billing period
+	AtPeriodEnd    bool    `json:"at_period_end"`
+	Reason         string  `json:"reason,omitempty"`
+	IdempotencyKey *string `json:"-"`
+}
+
+// bulkCancelRequest is the body of a bulk subscription cancellation; the options
+// are flattened next to the IDs
+type bulkCancelRequest struct {
+	SubscriptionIDs []string `json:"subscription_ids"`
+	CancelSubscriptionOptions
+}
+
+// PauseRequest represents the request model for pausing a subscription
+type PauseRequest struct {
+	// ResumesAt is when billing resumes automatically; nil pauses indefinitely
+	ResumesAt      *time.Time `json:"resumes_at,omitempty"`
+	IdempotencyKey *string    `json:"-"`
+}
+
+// Proration behaviors for subscription plan changes
+const (
+	// ProrationCreateProrations charges or credits the difference for the rest of the period
+	ProrationCreateProrations = "create_prorations"
+	// ProrationNone applies the new price from the next billing period
+	ProrationNone = "none"
+)
+
+// ChangePlanRequest represents the request model for moving a subscription to another product
+type ChangePlanRequest struct {
+	NewProductID      string  `json:"new_product_id"`
+	ProrationBehavior string  `json:"proration_behavior,omitempty"`
+	IdempotencyKey    *string `json:"-"`
+}
+
+// ProrationPreview represents the amounts a subscription plan change would produce
+type ProrationPreview struct {
+	AmountDue         float64 `json:"amount_due"`
+	Credits           float64 `json:"credits"`
+	NextBillingAmount float64 `json:"next_billing_amount"`
+	NextBillingDate   string  `json:"next_billing_date"`
+}
+
+// SubscriptionFilter represents optional filters for listing subscriptions.
+// The zero value applies no filtering. MaxItems caps the number of subscriptions
+// returned by ListAllSubscriptions and is not sent to the API (zero means no cap).
+// SortBy accepts SortByAmount or SortByCreatedAt; SortOrder SortOrderAsc or SortOrderDesc.
+// PageToken resumes listing from the NextPageToken of an earlier page; iterators and
+// the ListAll helpers manage it themselves and always start from the first page.
+type SubscriptionFilter struct {
+	Status        *string
+	ProductID     *string
+	CustomerEmail *string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	SortBy        *string
+	SortOrder     *string
+	PageToken     *string
+	MaxItems      int
+}
+
+// apply adds the non-nil filter fields to the query parameters
+func (f SubscriptionFilter) apply(params map[string]string) {
+	if f.Status != nil {
+		params["status"] = *f.Status
+	}
+	if f.ProductID != nil {
+		params["productId"] = *f.ProductID
+	}
+	if f.CustomerEmail != nil {
+		params["customerEmail"] = *f.CustomerEmail
+	}
+	if f.CreatedAfter != nil {
+		params["createdAfter"] = f.CreatedAfter.UTC().Format(time.RFC3339)
+	}
+	if f.CreatedBefore != nil {
+		params["createdBefore"] = f.CreatedBefore.UTC().Format(time.RFC3339)
+	}
+	if f.SortBy != nil {
+		params["sortBy"] = *f.SortBy
+	}
+	if f.SortOrder != nil {
+		params["sortOrder"] = *f.SortOrder
+	}
+	if f.PageToken != nil {
+		params["pageToken"] = *f.PageToken
+	}
 }
 
 // SubscriptionListResponse represents the subscription list response
 type SubscriptionListResponse struct {
-	Total int            `json:"total"`
-	Items []Subscription `json:"items"`
-	Code  int            `json:"code"`
-	Msg   string         `json:"msg"`
+	Total         int            `json:"total"`
+	Items         []Subscription `json:"items"`
+	HasMore       bool           `json:"has_more"`
+	NextPageToken *string        `json:"next_page_token,omitempty"`
+	Code          int            `json:"code"`
+	Msg           string         `json:"msg"`
+}
+
+// CustomerAddress represents a customer's postal address
+type CustomerAddress struct {
+	Line1      string `json:"line1,omitempty"`
+	Line2      string `json:"line2,omitempty"`
+	City       string `json:"city,omitempty"`
+	State      string `json:"state,omitempty"`
+	PostalCode string `json:"postal_code,omitempty"`
+	Country    string `json:"country,omitempty"`
+}
+
+// CustomerLifetimeValue represents a customer's spend in a single period
+type CustomerLifetimeValue struct {
+	Period   *string  `json:"period,omitempty"`
+	Amount   *float64 `json:"amount,omitempty"`
+	Currency *string  `json:"currency,omitempty"`
 }
 
 // CustomerData represents customer data model
 type CustomerData struct {
-	ID            *int     `json:"id,omitempty"`
-	Name          *string  `json:"name,omitempty"`
-	Email         *string  `json:"email,omitempty"`
-	Remark        *string  `json:"remark,omitempty"`
-	Subscriptions *int     `json:"subscriptions,omitempty"`
-	Payments      *int     `json:"payments,omitempty"`
-	StoreID       *string  `json:"store_id,omitempty"`
-	TotalSpend    *float64 `json:"total_spend,omitempty"`
-	CreatedAt     *string  `json:"created_at,omitempty"`
-	UpdatedAt     *string  `json:"updated_at,omitempty"`
+	ID                   *int                    `json:"id,omitempty"`
+	Name                 *string                 `json:"name,omitempty"`
+	Email                *string                 `json:"email,omitempty"`
+	Remark               *string                 `json:"remark,omitempty"`
+	Subscriptions        *int                    `json:"subscriptions,omitempty"`
+	Payments             *int                    `json:"payments,omitempty"`
+	StoreID              *string                 `json:"store_id,omitempty"`
+	TotalSpend           *float64                `json:"total_spend,omitempty"`
+	CreatedAt            *string                 `json:"created_at,omitempty"`
+	UpdatedAt            *string                 `json:"updated_at,omitempty"`
+	Phone                *string                 `json:"phone,omitempty"`
+	Country              *string                 `json:"country,omitempty"`
+	Address              *CustomerAddress        `json:"address,omitempty"`
+	Metadata             map[string]interface{}  `json:"metadata,omitempty"`
+	LifetimeValueHistory []CustomerLifetimeValue `json:"lifetime_value_history,omitempty"`
+}
+
+// FullName returns the customer's name for display, falling back to the email address
+// when the name is missing
+func (c CustomerData) FullName() string {
+	if c.Name != nil && strings.TrimSpace(*c.Name) != "" {
+		return *c.Name
+	}
+	if c.Email != nil {
+		return *c.Email
+	}
+	return ""
+}
+
+// ActiveSubscriptionValue returns the monthly value of the customer's subscriptions,
+// counted and normalised as by CalculateMRR. Subscriptions whose customer email is set
+// and differs from the customer's are skipped.
+func (c CustomerData) ActiveSubscriptionValue(subscriptions []Subscription) float64 {
+	owned := make([]Subscription, 0, len(subscriptions))
+	for _, s := range subscriptions {
+		if s.Customer != nil && s.Customer.Email != nil && c.Email != nil && !strings.EqualFold(*s.Customer.Email, *c.Email) {
+			continue
+		}
+		owned = append(owned, s)
+	}
+	return CalculateMRR(owned)
+}
+
+// CreateCustomerRequest represents the request model for creating a customer
+type CreateCustomerRequest struct {
+	Name           string                 `json:"name"`
+	Email          string                 `json:"email"`
+	Phone          string                 `json:"phone,omitempty"`
+	Country        string                 `json:"country,omitempty"`
+	Address        *CustomerAddress       `json:"address,omitempty"`
+	Metadata       map[string]interface{} `json:"metadata,omitempty"`
+	IdempotencyKey *string                `json:"-"`
+}
+
+// UpdateCustomerRequest represents the request model for updating a customer.
+// Nil fields are left unchanged.
+type UpdateCustomerRequest struct {
+	CustomerID     int                    `json:"customer_id"`
+	Name           *string                `json:"name,omitempty"`
+	Email          *string                `json:"email,omitempty"`
+	Phone          *string                `json:"phone,omitempty"`
+	Country        *string                `json:"country,omitempty"`
+	Address        *CustomerAddress       `json:"address,omitempty"`
+	Metadata       map[string]interface{} `json:"metadata,omitempty"`
+	IdempotencyKey *string                `json:"-"`
+}
+
+// CustomerPortalSession represents a single-use link to the customer self-service portal
+type CustomerPortalSession struct {
+	URL       *string `json:"url,omitempty"`
+	ExpiresAt *string `json:"expires_at,omitempty"`
+}
+
+// PaymentMethod represents a payment method saved for a customer
+type PaymentMethod struct {
+	ID        *string `json:"id,omitempty"`
+	Type      *string `json:"type,omitempty"`
+	Brand     *string `json:"brand,omitempty"`
+	Last4     *string `json:"last4,omitempty"`
+	ExpMonth  *int    `json:"exp_month,omitempty"`
+	ExpYear   *int    `json:"exp_year,omitempty"`
+	IsDefault *bool   `json:"is_default,omitempty"`
+	CreatedAt *string `json:"created_at,omitempty"`
+}
+
+// SupportedPaymentMethod represents a payment method type that checkouts can accept
+type SupportedPaymentMethod struct {
+	ID        *string  `json:"id,omitempty"`
+	Name      *string  `json:"name,omitempty"`
+	Countries []string `json:"countries,omitempty"`
+}
+
+// Currency represents a currency supported by the API
+type Currency struct {
+	Code          string  `json:"code"`
+	Name          string  `json:"name"`
+	Symbol        string  `json:"symbol"`
+	MinAmount     float64 `json:"min_amount"`
+	DecimalDigits int     `json:"decimal_digits"`
+}
+
+// TaxCategory represents a tax category products can be assigned to.
+// ID is the value to use for a product's TaxCategory field.
+type TaxCategory struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	DefaultRate float64 `json:"default_rate"`
+}
+
+// TaxRateRequest describes the sale GetTaxRate calculates tax for.
+// Country is an ISO 3166-1 alpha-2 code; State narrows it where tax varies by state.
+type TaxRateRequest struct {
+	Country     string
+	State       *string
+	TaxCategory string
+	Price       float64
+}
+
+// apply adds the request fields to the query parameters
+func (r TaxRateRequest) apply(params map[string]string) {
+	params["country"] = r.Country
+	if r.State != nil {
+		params["state"] = *r.State
+	}
+	params["taxCategory"] = r.TaxCategory
+	params["price"] = strconv.FormatFloat(r.Price, 'f', -1, 64)
+}
+
+// TaxRate represents the tax due on a price in a jurisdiction
+type TaxRate struct {
+	Rate         float64 `json:"rate"`
+	TaxAmount    float64 `json:"tax_amount"`
+	TaxInclusive bool    `json:"tax_inclusive"`
+	Country      string  `json:"country"`
+	State        *string `json:"state,omitempty"`
+}
+
+// CustomerFilter represents optional filters for listing customers.
+// The zero value applies no filtering. MaxItems caps the number of customers
+// returned by ListAllCustomers and is not sent to the API (zero means no cap).
+// SortBy accepts SortByEmail, SortByTotalSpend or SortByCreatedAt; SortOrder SortOrderAsc or SortOrderDesc.
+// PageToken resumes listing from the NextPageToken of an earlier page; iterators and
+// the ListAll helpers manage it themselves and always start from the first page.
+type CustomerFilter struct {
+	Email         *string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	MinTotalSpend *float64
+	SortBy        *string
+	SortOrder     *string
+	PageToken     *string
+	MaxItems      int
+}
+
+// apply adds the non-nil filter fields to the query parameters
+func (f CustomerFilter) apply(params map[string]string) {
+	if f.Email != nil {
+		params["email"] = *f.Email
+	}
+	if f.CreatedAfter != nil {
+		params["createdAfter"] = f.CreatedAfter.UTC().Format(time.RFC3339)
+	}
+	if f.CreatedBefore != nil {
+		params["createdBefore"] = f.CreatedBefore.UTC().Format(time.RFC3339)
+	}
+	if f.MinTotalSpend != nil {
+		params["minTotalSpend"] = strconv.FormatFloat(*f.MinTotalSpend, 'f', -1, 64)
+	}
+	if f.SortBy != nil {
+		params["sortBy"] = *f.SortBy
+	}
+	if f.SortOrder != nil {
+		params["sortOrder"] = *f.SortOrder
+	}
+	if f.PageToken != nil {
+		params["pageToken"] = *f.PageToken
+	}
 }
 
 // CustomerListResponse represents the customer list response
 type CustomerListResponse struct {
-	Total int            `json:"total"`
-	Items []CustomerData `json:"items"`
-	Code  int            `json:"code"`
-	Msg   string         `json:"msg"`
+	Total         int            `json:"total"`
+	Items         []CustomerData `json:"items"`
+	HasMore       bool           `json:"has_more"`
+	NextPageToken *string        `json:"next_page_token,omitempty"`
+	Code          int            `json:"code"`
+	Msg           string         `json:"msg"`
+}
+
+// WebhookRequest represents the request model for registering a webhook endpoint
+type WebhookRequest struct {
+	URL            string   `json:"url"`
+	Events         []string `json:"events"`
+	Secret         string   `json:"secret,omitempty"`
+	IdempotencyKey *string  `json:"-"`
+}
+
+// Webhook represents a registered webhook endpoint
+type Webhook struct {
+	ID        *string  `json:"id,omitempty"`
+	URL       *string  `json:"url,omitempty"`
+	Events    []string `json:"events,omitempty"`
+	IsActive  *bool    `json:"is_active,omitempty"`
+	CreatedAt *string  `json:"created_at,omitempty"`
+}
+
+// Webhook event delivery statuses
+const (
+	WebhookEventPending   = "pending"
+	WebhookEventDelivered = "delivered"
+	WebhookEventFailed    = "failed"
+)
+
+// WebhookEvent represents an event sent to a webhook endpoint and the state of its delivery
+type WebhookEvent struct {
+	EventID            *string         `json:"event_id,omitempty"`
+	WebhookID          *string         `json:"webhook_id,omitempty"`
+	EventType          *string         `json:"event_type,omitempty"`
+	Payload            json.RawMessage `json:"payload,omitempty"`
+	Status             *string         `json:"status,omitempty"`
+	Attempts           *int            `json:"attempts,omitempty"`
+	ResponseStatusCode *int            `json:"response_status_code,omitempty"`
+	LastAttemptAt      *string         `json:"last_attempt_at,omitempty"`
+	CreatedAt          *string         `json:"created_at,omitempty"`
+}
+
+// WebhookEventListResponse represents the webhook event list response
+type WebhookEventListResponse struct {
+	Total         int            `json:"total"`
+	Items         []WebhookEvent `json:"items"`
+	HasMore       bool           `json:"has_more"`
+	NextPageToken *string        `json:"next_page_token,omitempty"`
+	Code          int            `json:"code"`
+	Msg           string         `json:"msg"`
+}
+
+// Revenue summary periods
+const (
+	PeriodToday      = "today"
+	PeriodThisWeek   = "this_week"
+	PeriodThisMonth  = "this_month"
+	PeriodLast30Days = "last_30_days"
+	PeriodThisYear   = "this_year"
+)
+
+// RevenueSummary represents aggregate revenue figures for a period
+type RevenueSummary struct {
+	GrossRevenue         *float64 `json:"gross_revenue,omitempty"`
+	NetRevenue           *float64 `json:"net_revenue,omitempty"`
+	TotalFees            *float64 `json:"total_fees,omitempty"`
+	TotalTax             *float64 `json:"total_tax,omitempty"`
+	TotalRefunds         *float64 `json:"total_refunds,omitempty"`
+	TransactionCount     *int     `json:"transaction_count,omitempty"`
+	NewSubscriptions     *int     `json:"new_subscriptions,omitempty"`
+	ChurnedSubscriptions *int     `json:"churned_subscriptions,omitempty"`
+}
+
+// BalanceAmount represents funds held in one currency
+type BalanceAmount struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+// Balance represents the merchant's funds: Available can be paid out, Pending has not settled yet
+type Balance struct {
+	Available []BalanceAmount `json:"available"`
+	Pending   []BalanceAmount `json:"pending"`
+}
+
+// PayoutBankAccount identifies the bank account a payout was sent to
+type PayoutBankAccount struct {
+	BankName *string `json:"bank_name,omitempty"`
+	Last4    *string `json:"last4,omitempty"`
+}
+
+// Payout represents a transfer of funds from BagelPay to the merchant's bank account
+type Payout struct {
+	PayoutID    *string            `json:"payout_id,omitempty"`
+	Amount      *float64           `json:"amount,omitempty"`
+	Currency    *string            `json:"currency,omitempty"`
+	Status      *string            `json:"status,omitempty"`
+	ArrivalDate *string            `json:"arrival_date,omitempty"`
+	BankAccount *PayoutBankAccount `json:"bank_account,omitempty"`
+	CreatedAt   *string            `json:"created_at,omitempty"`
+}
+
+// PayoutListResponse represents the payout list response
+type PayoutListResponse struct {
+	Total         int      `json:"total"`
+	Items         []Payout `json:"items"`
+	HasMore       bool     `json:"has_more"`
+	NextPageToken *string  `json:"next_page_token,omitempty"`
+	Code          int      `json:"code"`
+	Msg           string   `json:"msg"`
+}
+
+// StoreTaxSettings represents the tax configuration of a store
+type StoreTaxSettings struct {
+	TaxInclusive       *bool   `json:"tax_inclusive,omitempty"`
+	DefaultTaxCategory *string `json:"default_tax_category,omitempty"`
+}
+
+// StoreInfo represents the store an API key belongs to
+type StoreInfo struct {
+	StoreID         *string           `json:"store_id,omitempty"`
+	Name            *string           `json:"name,omitempty"`
+	DefaultCurrency *string           `json:"default_currency,omitempty"`
+	Mode            *string           `json:"mode,omitempty"`
+	Timezone        *string           `json:"timezone,omitempty"`
+	TaxSettings     *StoreTaxSettings `json:"tax_settings,omitempty"`
+	WebhookURLs     []string          `json:"webhook_urls,omitempty"`
+	CreatedAt       *string           `json:"created_at,omitempty"`
+}
+
+// BulkError describes why one item of a bulk operation failed
+type BulkError struct {
+	ID    string `json:"id"`
+	Error string `json:"error"`
+}
+
+// BulkOperationResult reports the outcome of a bulk operation per item
+type BulkOperationResult struct {
+	Succeeded []string    `json:"succeeded"`
+	Failed    []BulkError `json:"failed"`
+}
+
+// APIKeyInfo describes an API key as reported by ValidateAPIKey
+type APIKeyInfo struct {
+	IsValid   bool     `json:"is_valid"`
+	Mode      string   `json:"mode"`
+	StoreID   string   `json:"store_id"`
+	ExpiresAt *string  `json:"expires_at,omitempty"`
+	Scopes    []string `json:"scopes,omitempty"`
 }
 
 // APIError represents an API error response
@@ -228,6 +1375,32 @@ func BoolPtr(b bool) *bool {
 	return &b
 }
 
+// timestampLayouts lists the layouts accepted for API timestamps, most common first.
+// Layouts without a zone are interpreted as UTC.
+var timestampLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"}
+
+// parseTimestamp parses an API timestamp field
+func parseTimestamp(s *string) (time.Time, error) {
+	if s == nil || strings.TrimSpace(*s) == "" {
+		return time.Time{}, NewBagelPayError("timestamp is missing", nil)
+	}
+	value := strings.TrimSpace(*s)
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, NewBagelPayError(fmt.Sprintf("unrecognised timestamp %q", value), nil)
+}
+
+// derefFloat returns *f, or 0 when f is nil
+func derefFloat(f *float64) float64 {
+	if f == nil {
+		return 0
+	}
+	return *f
+}
+
 // ToJSON converts a struct to JSON string
 func ToJSON(v interface{}) (string, error) {
 	b, err := json.Marshal(v)
@@ -0,0 +1,36 @@
+package bagelpay
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTransactionTypeRoundTrip(t *testing.T) {
+	var tx Transaction
+	if err := json.Unmarshal([]byte(`{"transaction_id":"t1","type":"refund"}`), &tx); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if tx.Type == nil || *tx.Type != TransactionTypeRefund {
+		t.Errorf("Type = %v, want %q", tx.Type, TransactionTypeRefund)
+	}
+
+	txType := TransactionTypePayment
+	params := map[string]string{}
+	TransactionFilter{Type: &txType}.apply(params)
+	if params["type"] != "payment" {
+		t.Errorf("type param = %q, want payment", params["type"])
+	}
+}
+
+func TestSubscriptionIsCancelled(t *testing.T) {
+	var sub Subscription
+	if err := json.Unmarshal([]byte(`{"status":"canceled"}`), &sub); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !sub.IsCancelled() {
+		t.Error("subscription with status canceled is not IsCancelled")
+	}
+	if sub.Status.IsActive() {
+		t.Error("cancelled status reported as active")
+	}
+}
@@ -0,0 +1,72 @@
+package bagelpay
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Option configures a client created by NewClientWithOptions
+type Option func(*ClientConfig)
+
+// NewClientWithOptions creates a new BagelPay API client configured by functional options.
+// The client starts in test mode; pass WithLiveMode to talk to the live API.
+func NewClientWithOptions(apiKey string, opts ...Option) *BagelPayClient {
+	config := ClientConfig{
+		APIKey:   apiKey,
+		TestMode: true,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return NewClient(config)
+}
+
+// WithTestMode sends requests to the test environment
+func WithTestMode() Option {
+	return func(c *ClientConfig) {
+		c.TestMode = true
+	}
+}
+
+// WithLiveMode sends requests to the live environment
+func WithLiveMode() Option {
+	return func(c *ClientConfig) {
+		c.TestMode = false
+	}
+}
+
+// WithBaseURL overrides the API base URL, taking precedence over the mode
+func WithBaseURL(u string) Option {
+	return func(c *ClientConfig) {
+		c.BaseURL = u
+	}
+}
+
+// WithTimeout sets the default request timeout
+func WithTimeout(d time.Duration) Option {
+	return func(c *ClientConfig) {
+		c.Timeout = d
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send requests
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *ClientConfig) {
+		c.HTTPClient = hc
+	}
+}
+
+// WithRetry sets the retry configuration for rate-limited requests
+func WithRetry(cfg RetryConfig) Option {
+	return func(c *ClientConfig) {
+		c.Retry = &cfg
+	}
+}
+
+// WithLogger sets the structured logger for requests, responses, and retries
+func WithLogger(l *slog.Logger) Option {
+	return func(c *ClientConfig) {
+		c.Logger = l
+	}
+}
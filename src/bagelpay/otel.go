@@ -0,0 +1,73 @@
+//go:build otel
+
+package bagelpay
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the SDK as the source of its spans
+const instrumentationName = "github.com/bagelpay/bagelpay-sdk-go"
+
+func init() {
+	newRequestTracer = func(provider interface{}) requestTracer {
+		tp, ok := provider.(trace.TracerProvider)
+		if !ok || tp == nil {
+			return nil
+		}
+		return &otelTracer{
+			tracer: tp.Tracer(instrumentationName, trace.WithInstrumentationVersion(Version)),
+		}
+	}
+	checkTracerProvider = func(provider interface{}) error {
+		if provider == nil {
+			return nil
+		}
+		if _, ok := provider.(trace.TracerProvider); !ok {
+			return fmt.Errorf("%T is not an OpenTelemetry trace.TracerProvider", provider)
+		}
+		return nil
+	}
+}
+
+// otelTracer records a client span for every request and propagates the W3C trace context
+type otelTracer struct {
+	tracer trace.Tracer
+}
+
+func (t *otelTracer) start(req *http.Request, endpoint string) (*http.Request, func(resp *http.Response, err error)) {
+	ctx, span := t.tracer.Start(req.Context(), "bagelpay."+req.Method+" "+endpoint,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.method", req.Method),
+			attribute.String("http.url", req.URL.String()),
+		),
+	)
+
+	req = req.WithContext(ctx)
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	return req, func(resp *http.Response, err error) {
+		defer span.End()
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return
+		}
+
+		span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+		if requestID := resp.Header.Get(RequestIDHeader); requestID != "" {
+			span.SetAttributes(attribute.String("bagelpay.request_id", requestID))
+		}
+		if resp.StatusCode >= 400 {
+			span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+		}
+	}
+}
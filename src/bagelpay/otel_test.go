@@ -0,0 +1,28 @@
+//go:build otel
+
+package bagelpay
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestValidateTracerProvider(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider interface{}
+		wantErr  bool
+	}{
+		{"none", nil, false},
+		{"noop provider", noop.NewTracerProvider(), false},
+		{"tracer instead of provider", noop.NewTracerProvider().Tracer("x"), true},
+		{"string", "otel", true},
+	}
+	for _, tt := range tests {
+		err := ClientConfig{APIKey: "k", TracerProvider: tt.provider}.Validate()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package bagelpay
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// GenerateCheckoutQRCodeDataURL encodes the checkout URL as a square PNG QR code of
+// size x size pixels and returns it as a data:image/png;base64 URL, ready to use as
+// an <img> src. No network call is made.
+func GenerateCheckoutQRCodeDataURL(checkoutURL string, size int) (string, error) {
+	if !isAbsoluteURL(checkoutURL) {
+		return "", newValidationError(fmt.Sprintf("checkout url must be an absolute URL, got %q", checkoutURL))
+	}
+	if size <= 0 {
+		return "", newValidationError(fmt.Sprintf("size must be positive, got %d", size))
+	}
+
+	png, err := qrcode.Encode(checkoutURL, qrcode.Medium, size)
+	if err != nil {
+		return "", NewBagelPayError("failed to encode QR code", err)
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(png), nil
+}
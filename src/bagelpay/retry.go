@@ -0,0 +1,107 @@
+package bagelpay
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryConfig controls how rate-limited (HTTP 429) requests are retried
+type RetryConfig struct {
+	// MaxRetries is the maximum number of retries after the first attempt (0 disables retries)
+	MaxRetries int
+	// BaseDelay is the initial backoff delay, doubled on every attempt (default: 500ms)
+	BaseDelay time.Duration
+	// MaxDelay caps a single backoff delay (default: 30 seconds). A server that asks
+	// for a longer wait with Retry-After is not retried; the rate-limit error is returned.
+	MaxDelay time.Duration
+	// Jitter enables full jitter, picking a random delay between zero and the backoff value
+	Jitter bool
+}
+
+// DefaultRetryConfig returns the retry configuration used when ClientConfig.Retry is nil
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxRetries: DefaultMaxRetries,
+		BaseDelay:  DefaultRetryBaseDelay,
+		MaxDelay:   DefaultRetryMaxDelay,
+		Jitter:     true,
+	}
+}
+
+// withDefaults fills in zero delays with the package defaults
+func (r RetryConfig) withDefaults() RetryConfig {
+	if r.BaseDelay <= 0 {
+		r.BaseDelay = DefaultRetryBaseDelay
+	}
+	if r.MaxDelay <= 0 {
+		r.MaxDelay = DefaultRetryMaxDelay
+	}
+	if r.MaxDelay < r.BaseDelay {
+		r.MaxDelay = r.BaseDelay
+	}
+	return r
+}
+
+// delay returns how long to wait before the given retry attempt (starting at 0).
+// A Retry-After header sent by the server takes precedence over the computed backoff;
+// ok is false when it asks for longer than MaxDelay, since retrying any sooner would
+// only be rejected again.
+func (r RetryConfig) delay(attempt int, header http.Header) (wait time.Duration, ok bool) {
+	if wait, ok := parseRetryAfter(header); ok {
+		return wait, wait <= r.MaxDelay
+	}
+
+	// Truncated exponential backoff: BaseDelay * 2^attempt, capped at MaxDelay
+	backoff := r.MaxDelay
+	if attempt < 32 {
+		if d := r.BaseDelay << uint(attempt); d > 0 && d < r.MaxDelay {
+			backoff = d
+		}
+	}
+
+	if r.Jitter {
+		return time.Duration(rand.Int63n(int64(backoff) + 1)), true
+	}
+	return backoff, true
+}
+
+// parseRetryAfter parses a Retry-After header expressed either in seconds or as an HTTP-date
+func parseRetryAfter(header http.Header) (time.Duration, bool) {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	// A date in the past means the caller may retry immediately
+	if wait := time.Until(date); wait > 0 {
+		return wait, true
+	}
+	return 0, true
+}
+
+// sleepContext waits for the given duration or until the context is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
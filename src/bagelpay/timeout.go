@@ -0,0 +1,44 @@
+package bagelpay
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// requestTimeoutKey is the context key for per-request timeout overrides
+type requestTimeoutKey struct{}
+
+// WithRequestTimeout returns a context that overrides ClientConfig.Timeout for
+// API calls made with it. The deadline is computed when each request is sent.
+//
+// Wrapping a call with context.WithTimeout(ctx, 5*time.Second) works as well:
+// the client honours any deadline already present on the context.
+func WithRequestTimeout(ctx context.Context, d time.Duration) context.Context {
+	return context.WithValue(ctx, requestTimeoutKey{}, d)
+}
+
+// requestContext applies the effective timeout for a single request.
+// An explicit WithRequestTimeout override wins; otherwise an existing context
+// deadline is honoured and the client timeout only applies when there is none.
+func (c *BagelPayClient) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if timeout, ok := ctx.Value(requestTimeoutKey{}).(time.Duration); ok && timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	if _, ok := ctx.Deadline(); ok || c.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+// cancelOnClose releases a request context once the response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,28 @@
+package bagelpay
+
+import (
+	"errors"
+	"net/http"
+)
+
+// requestTracer instruments individual HTTP requests made by the client
+type requestTracer interface {
+	// start begins a span for the request and returns the request to send
+	// along with a function that ends the span once the response arrives
+	start(req *http.Request, endpoint string) (*http.Request, func(resp *http.Response, err error))
+}
+
+// newRequestTracer builds a requestTracer from ClientConfig.TracerProvider.
+// Without the otel build tag tracing is compiled out and this returns nil.
+var newRequestTracer = func(provider interface{}) requestTracer {
+	return nil
+}
+
+// checkTracerProvider reports why ClientConfig.TracerProvider cannot trace requests.
+// Without the otel build tag any provider is rejected rather than silently ignored.
+var checkTracerProvider = func(provider interface{}) error {
+	if provider == nil {
+		return nil
+	}
+	return errors.New("tracing requires building the SDK with the otel build tag")
+}
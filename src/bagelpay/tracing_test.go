@@ -0,0 +1,15 @@
+//go:build !otel
+
+package bagelpay
+
+import "testing"
+
+func TestValidateRejectsTracerProviderWithoutOtelTag(t *testing.T) {
+	err := ClientConfig{APIKey: "k", TracerProvider: struct{}{}}.Validate()
+	if err == nil || !IsValidationError(err) {
+		t.Fatalf("Validate() = %v, want a validation error", err)
+	}
+	if err := (ClientConfig{APIKey: "k"}).Validate(); err != nil {
+		t.Errorf("Validate() without a tracer provider = %v", err)
+	}
+}
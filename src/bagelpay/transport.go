@@ -0,0 +1,82 @@
+package bagelpay
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// hasTransportOptions reports whether the config sets any option that NewClient
+// applies to a transport of its own
+func (c ClientConfig) hasTransportOptions() bool {
+	return c.MaxIdleConns != 0 || c.MaxIdleConnsPerHost != 0 || c.IdleConnTimeout != 0 || c.DisableKeepAlives ||
+		c.TLSConfig != nil || c.ProxyURL != "" || c.DisableHTTP2
+}
+
+// parseProxyURL parses ClientConfig.ProxyURL, accepting http, https, and socks5 proxies
+func parseProxyURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if !containsString([]string{"http", "https", "socks5"}, u.Scheme) {
+		return nil, fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("missing host")
+	}
+	return u, nil
+}
+
+// newTransport builds the transport for a client without a caller-supplied HTTPClient.
+// It returns nil, meaning http.DefaultTransport, when no transport option is set.
+func newTransport(config ClientConfig) http.RoundTripper {
+	if !config.hasTransportOptions() {
+		return nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.MaxIdleConns != 0 {
+		transport.MaxIdleConns = config.MaxIdleConns
+	}
+	if config.MaxIdleConnsPerHost != 0 {
+		transport.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+	}
+	if config.IdleConnTimeout != 0 {
+		transport.IdleConnTimeout = config.IdleConnTimeout
+	}
+	transport.DisableKeepAlives = config.DisableKeepAlives
+	if config.TLSConfig != nil {
+		transport.TLSClientConfig = config.TLSConfig
+	}
+	if config.ProxyURL != "" {
+		// Validate has already rejected malformed proxy URLs
+		if proxy, err := parseProxyURL(config.ProxyURL); err == nil {
+			transport.Proxy = http.ProxyURL(proxy)
+		}
+	}
+	if config.DisableHTTP2 {
+		disableHTTP2(transport)
+	}
+	return transport
+}
+
+// disableHTTP2 restricts the transport to HTTP/1.1. A non-nil empty TLSNextProto
+// stops net/http from enabling HTTP/2, and "h2" must also be dropped from the ALPN
+// protocols, which a clone of an already used transport may still advertise.
+func disableHTTP2(transport *http.Transport) {
+	transport.ForceAttemptHTTP2 = false
+	transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	if transport.TLSClientConfig != nil {
+		tlsConfig := transport.TLSClientConfig.Clone()
+		protos := tlsConfig.NextProtos[:0:0]
+		for _, proto := range tlsConfig.NextProtos {
+			if proto != "h2" {
+				protos = append(protos, proto)
+			}
+		}
+		tlsConfig.NextProtos = protos
+		transport.TLSClientConfig = tlsConfig
+	}
+}
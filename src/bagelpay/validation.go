@@ -0,0 +1,454 @@
+package bagelpay
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// knownRecurringIntervals lists the recurring intervals accepted by the API
+var knownRecurringIntervals = []string{IntervalDaily, IntervalWeekly, IntervalMonthly, Interval3Months, Interval6Months, IntervalYearly}
+
+// knownPaymentMethods lists the payment method names accepted in CheckoutRequest.AllowedPaymentMethods.
+// ListSupportedPaymentMethods reports which of them are available to the merchant.
+var knownPaymentMethods = []string{"card", "sepa_debit", "ach_debit", "paypal", "apple_pay", "google_pay", "ideal", "bancontact", "klarna", "alipay", "wechat_pay"}
+
+// knownRevenuePeriods lists the periods accepted by GetRevenueSummary
+var knownRevenuePeriods = []string{PeriodToday, PeriodThisWeek, PeriodThisMonth, PeriodLast30Days, PeriodThisYear}
+
+// Sort fields accepted by each list filter
+var (
+	knownProductSortFields      = []string{SortByPrice, SortByName, SortByCreatedAt}
+	knownTransactionSortFields  = []string{SortByAmount, SortByCreatedAt}
+	knownSubscriptionSortFields = []string{SortByAmount, SortByCreatedAt}
+	knownCustomerSortFields     = []string{SortByEmail, SortByTotalSpend, SortByCreatedAt}
+)
+
+// Bounds for CheckoutRequest.ExpiresInMinutes: five minutes to one day
+const (
+	minCheckoutExpiryMinutes = 5
+	maxCheckoutExpiryMinutes = 24 * 60
+)
+
+// Validate checks the client configuration for mistakes that would make every request fail
+func (c ClientConfig) Validate() error {
+	if strings.TrimSpace(c.APIKey) == "" {
+		return newValidationError("api key is required")
+	}
+	if c.Timeout != 0 && c.Timeout < time.Millisecond {
+		return newValidationError(fmt.Sprintf("timeout must be at least 1ms, got %s", c.Timeout))
+	}
+	if c.Retry != nil && c.Retry.MaxRetries < 0 {
+		return newValidationError(fmt.Sprintf("max retries must not be negative, got %d", c.Retry.MaxRetries))
+	}
+	if c.MaxResponseBodyBytes < 0 {
+		return newValidationError(fmt.Sprintf("max response body bytes must not be negative, got %d", c.MaxResponseBodyBytes))
+	}
+	if c.MaxIdleConns < 0 || c.MaxIdleConnsPerHost < 0 || c.IdleConnTimeout < 0 {
+		return newValidationError("connection pool options must not be negative")
+	}
+	if c.ProxyURL != "" {
+		if _, err := parseProxyURL(c.ProxyURL); err != nil {
+			return newValidationError(fmt.Sprintf("invalid proxy url %q: %v", c.ProxyURL, err))
+		}
+	}
+	if c.HTTPClient != nil && c.hasTransportOptions() {
+		return newValidationError("transport options cannot be combined with a custom HTTPClient; configure its transport instead")
+	}
+	if err := checkTracerProvider(c.TracerProvider); err != nil {
+		return newValidationError(fmt.Sprintf("invalid tracer provider: %v", err))
+	}
+	if c.BaseURL != "" && !c.TestMode {
+		u, err := url.Parse(c.BaseURL)
+		if err != nil || u.Scheme != "https" {
+			return newValidationError(fmt.Sprintf("base url must use https in live mode, got %q", c.BaseURL))
+		}
+	}
+	return nil
+}
+
+// Validate checks the request for missing or out-of-range fields without calling the API
+func (r CreateProductRequest) Validate() error {
+	if err := validateProductFields(r.Name, r.Price, r.Currency, r.BillingType, r.RecurringInterval, r.TrialDays); err != nil {
+		return err
+	}
+	return validateProductAttributes(r.ImageURL, r.Tags, r.Metadata)
+}
+
+// Validate checks the request for missing or out-of-range fields without calling the API
+func (r UpdateProductRequest) Validate() error {
+	if strings.TrimSpace(r.ProductID) == "" {
+		return newValidationError("product_id is required")
+	}
+	if err := validateProductFields(r.Name, r.Price, r.Currency, r.BillingType, r.RecurringInterval, r.TrialDays); err != nil {
+		return err
+	}
+	return validateProductAttributes(r.ImageURL, r.Tags, r.Metadata)
+}
+
+// Validate checks the fields that are set for out-of-range values without calling the API
+func (r PatchProductRequest) Validate() error {
+	if strings.TrimSpace(r.ProductID) == "" {
+		return newValidationError("product_id is required")
+	}
+	if err := validateOptionalProductFields(r.Name, r.Price, r.Currency, r.BillingType, r.RecurringInterval, r.TrialDays); err != nil {
+		return err
+	}
+	return validateOptionalProductAttributes(r.ImageURL, r.Tags, r.Metadata)
+}
+
+// Validate checks the overrides for out-of-range fields without calling the API
+func (o ProductOverrides) Validate() error {
+	if err := validateOptionalProductFields(o.Name, o.Price, o.Currency, o.BillingType, o.RecurringInterval, o.TrialDays); err != nil {
+		return err
+	}
+	return validateOptionalProductAttributes(o.ImageURL, o.Tags, o.Metadata)
+}
+
+// Validate checks the request for missing or out-of-range fields without calling the API
+func (r CheckoutRequest) Validate() error {
+	if strings.TrimSpace(r.ProductID) == "" {
+		return newValidationError("product_id is required")
+	}
+	if err := r.Customer.validate(); err != nil {
+		return err
+	}
+	if r.Units != nil {
+		units, err := strconv.Atoi(*r.Units)
+		if err != nil || units < 1 {
+			return newValidationError(fmt.Sprintf("units must be a positive integer, got %q", *r.Units))
+		}
+	}
+	if r.CancelURL != nil && !isAbsoluteURL(*r.CancelURL) {
+		return newValidationError(fmt.Sprintf("cancel_url must be an absolute URL, got %q", *r.CancelURL))
+	}
+	if r.CustomerIPAddress != nil && net.ParseIP(*r.CustomerIPAddress) == nil {
+		return newValidationError(fmt.Sprintf("customer_ip_address must be an IPv4 or IPv6 address, got %q", *r.CustomerIPAddress))
+	}
+	if len(r.ShippingCountries) > 0 && (r.CollectShipping == nil || !*r.CollectShipping) {
+		return newValidationError("shipping_countries requires collect_shipping to be true")
+	}
+	for _, country := range r.ShippingCountries {
+		if !isCountryCode(country) {
+			return newValidationError(fmt.Sprintf("shipping_countries must hold ISO 3166-1 alpha-2 codes, got %q", country))
+		}
+	}
+	if r.ExpiresInMinutes != nil && (*r.ExpiresInMinutes < minCheckoutExpiryMinutes || *r.ExpiresInMinutes > maxCheckoutExpiryMinutes) {
+		return newValidationError(fmt.Sprintf("expires_in_minutes must be between %d and %d, got %d", minCheckoutExpiryMinutes, maxCheckoutExpiryMinutes, *r.ExpiresInMinutes))
+	}
+	if r.PaymentMethodToken != nil && strings.TrimSpace(*r.PaymentMethodToken) == "" {
+		return newValidationError("payment_method_token must not be empty")
+	}
+	if r.Locale != nil {
+		if _, err := language.Parse(*r.Locale); err != nil {
+			return newValidationError(fmt.Sprintf("locale must be a BCP 47 language tag, got %q", *r.Locale))
+		}
+	}
+	for _, method := range r.AllowedPaymentMethods {
+		if !containsString(knownPaymentMethods, method) {
+			return newValidationError(fmt.Sprintf("unrecognised payment method %q in allowed_payment_methods", method))
+		}
+	}
+	return nil
+}
+
+// Validate checks the request for missing or out-of-range fields without calling the API
+func (r CartCheckoutRequest) Validate() error {
+	if len(r.Items) == 0 {
+		return newValidationError("at least one item is required")
+	}
+	for i, item := range r.Items {
+		if strings.TrimSpace(item.ProductID) == "" {
+			return newValidationError(fmt.Sprintf("items[%d]: product_id is required", i))
+		}
+		if item.Quantity < 1 {
+			return newValidationError(fmt.Sprintf("items[%d]: quantity must be positive, got %d", i, item.Quantity))
+		}
+	}
+	if err := r.Customer.validate(); err != nil {
+		return err
+	}
+	if r.CancelURL != nil && !isAbsoluteURL(*r.CancelURL) {
+		return newValidationError(fmt.Sprintf("cancel_url must be an absolute URL, got %q", *r.CancelURL))
+	}
+	return nil
+}
+
+// Validate checks the request for missing or out-of-range fields without calling the API
+func (r CouponRequest) Validate() error {
+	if strings.TrimSpace(r.Code) == "" {
+		return newValidationError("code is required")
+	}
+	switch r.DiscountType {
+	case DiscountTypePercent:
+		if r.DiscountValue <= 0 || r.DiscountValue > 100 {
+			return newValidationError(fmt.Sprintf("percent discount_value must be in (0, 100], got %v", r.DiscountValue))
+		}
+	case DiscountTypeFixed:
+		if r.DiscountValue <= 0 {
+			return newValidationError(fmt.Sprintf("fixed discount_value must be positive, got %v", r.DiscountValue))
+		}
+	default:
+		return newValidationError(fmt.Sprintf("unrecognised discount_type %q", r.DiscountType))
+	}
+	if r.MaxUses != nil && *r.MaxUses < 1 {
+		return newValidationError(fmt.Sprintf("max_uses must be positive, got %d", *r.MaxUses))
+	}
+	return nil
+}
+
+// Validate checks that the evidence is not empty without calling the API
+func (e DisputeEvidence) Validate() error {
+	for _, field := range []*string{e.ProductDescription, e.CustomerCommunication, e.RefundPolicy, e.ServiceDate, e.ShippingTrackingNumber, e.AdditionalInfo} {
+		if field != nil && strings.TrimSpace(*field) != "" {
+			return nil
+		}
+	}
+	return newValidationError("at least one evidence field is required")
+}
+
+// Validate checks the options for out-of-range fields without calling the API
+func (o PaymentLinkOptions) Validate() error {
+	if o.Slug != nil && !isSlug(*o.Slug) {
+		return newValidationError(fmt.Sprintf("slug must be lowercase letters and digits separated by single hyphens, got %q", *o.Slug))
+	}
+	if o.MaxUses != nil && *o.MaxUses < 1 {
+		return newValidationError(fmt.Sprintf("max_uses must be positive, got %d", *o.MaxUses))
+	}
+	return o.Customer.validate()
+}
+
+// Validate checks the request for missing or out-of-range fields without calling the API
+func (r TaxRateRequest) Validate() error {
+	if strings.TrimSpace(r.Country) == "" {
+		return newValidationError("country is required")
+	}
+	if strings.TrimSpace(r.TaxCategory) == "" {
+		return newValidationError("tax_category is required")
+	}
+	if r.Price < 0 {
+		return newValidationError(fmt.Sprintf("price must not be negative, got %v", r.Price))
+	}
+	return nil
+}
+
+// Validate checks the tags and sort parameters without calling the API
+func (f ProductFilter) Validate() error {
+	if err := validateTags(f.Tags); err != nil {
+		return err
+	}
+	return validateSort(f.SortBy, f.SortOrder, knownProductSortFields)
+}
+
+// Validate checks the sort parameters without calling the API
+func (f TransactionFilter) Validate() error {
+	return validateSort(f.SortBy, f.SortOrder, knownTransactionSortFields)
+}
+
+// Validate checks the sort parameters without calling the API
+func (f SubscriptionFilter) Validate() error {
+	return validateSort(f.SortBy, f.SortOrder, knownSubscriptionSortFields)
+}
+
+// Validate checks the sort parameters without calling the API
+func (f CustomerFilter) Validate() error {
+	return validateSort(f.SortBy, f.SortOrder, knownCustomerSortFields)
+}
+
+// validateImageURL checks that a product image, when given, is served over HTTPS
+func validateImageURL(imageURL *string) error {
+	if imageURL == nil {
+		return nil
+	}
+	u, err := url.Parse(*imageURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return newValidationError(fmt.Sprintf("image_url must be an absolute https URL, got %q", *imageURL))
+	}
+	return nil
+}
+
+// maxMetadataDepth is how deeply metadata objects may nest, counting the top level
+const maxMetadataDepth = 2
+
+// validateMetadata checks that metadata values are strings, numbers, or objects of
+// them nested at most maxMetadataDepth levels deep
+func validateMetadata(path string, metadata map[string]interface{}, depth int) error {
+	for key, value := range metadata {
+		field := path + "." + key
+		switch v := value.(type) {
+		case string, json.Number, float32, float64, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		case map[string]interface{}:
+			if depth >= maxMetadataDepth {
+				return newValidationError(fmt.Sprintf("%s: metadata must not nest more than %d levels", field, maxMetadataDepth))
+			}
+			if err := validateMetadata(field, v, depth+1); err != nil {
+				return err
+			}
+		default:
+			return newValidationError(fmt.Sprintf("%s: metadata values must be strings or numbers, got %T", field, value))
+		}
+	}
+	return nil
+}
+
+// validateTags checks that no tag is blank or contains the comma used to join tags in queries
+func validateTags(tags []string) error {
+	for i, tag := range tags {
+		if strings.TrimSpace(tag) == "" {
+			return newValidationError(fmt.Sprintf("tags[%d] must not be empty", i))
+		}
+		if strings.Contains(tag, ",") {
+			return newValidationError(fmt.Sprintf("tags[%d] must not contain a comma, got %q", i, tag))
+		}
+	}
+	return nil
+}
+
+// validateSort checks the sort field against the fields accepted by a list endpoint
+func validateSort(sortBy, sortOrder *string, fields []string) error {
+	if sortBy != nil && !containsString(fields, *sortBy) {
+		return newValidationError(fmt.Sprintf("unrecognised sort field %q, expected one of %s", *sortBy, strings.Join(fields, ", ")))
+	}
+	if sortOrder != nil && *sortOrder != SortOrderAsc && *sortOrder != SortOrderDesc {
+		return newValidationError(fmt.Sprintf("sort order must be %q or %q, got %q", SortOrderAsc, SortOrderDesc, *sortOrder))
+	}
+	return nil
+}
+
+// validate checks the checkout customer, which is optional
+func (c *Customer) validate() error {
+	if c == nil {
+		return nil
+	}
+	if strings.TrimSpace(c.Email) == "" {
+		return newValidationError("customer email is required when a customer is given")
+	}
+	if c.Country != nil && !isCountryCode(*c.Country) {
+		return newValidationError(fmt.Sprintf("customer country must be an ISO 3166-1 alpha-2 code, got %q", *c.Country))
+	}
+	return nil
+}
+
+// validateProductFields checks the fields shared by product create and update requests
+func validateProductFields(name string, price float64, currency, billingType, recurringInterval string, trialDays int) error {
+	if strings.TrimSpace(name) == "" {
+		return newValidationError("name is required")
+	}
+	if price < 0 {
+		return newValidationError(fmt.Sprintf("price must not be negative, got %v", price))
+	}
+	if strings.TrimSpace(currency) == "" {
+		return newValidationError("currency is required")
+	}
+	if !ValidBillingType(billingType) {
+		return newValidationError(fmt.Sprintf("unrecognised billing_type %q", billingType))
+	}
+	if billingType == BillingTypeSubscription && !containsString(knownRecurringIntervals, recurringInterval) {
+		return newValidationError(fmt.Sprintf("unrecognised recurring_interval %q", recurringInterval))
+	}
+	if trialDays < 0 {
+		return newValidationError(fmt.Sprintf("trial_days must not be negative, got %d", trialDays))
+	}
+	return nil
+}
+
+// validateOptionalProductFields checks the product fields that are set in a partial update
+func validateOptionalProductFields(name *string, price *float64, currency, billingType, recurringInterval *string, trialDays *int) error {
+	if name != nil && strings.TrimSpace(*name) == "" {
+		return newValidationError("name must not be empty")
+	}
+	if price != nil && *price < 0 {
+		return newValidationError(fmt.Sprintf("price must not be negative, got %v", *price))
+	}
+	if currency != nil && strings.TrimSpace(*currency) == "" {
+		return newValidationError("currency must not be empty")
+	}
+	if billingType != nil && !ValidBillingType(*billingType) {
+		return newValidationError(fmt.Sprintf("unrecognised billing_type %q", *billingType))
+	}
+	if recurringInterval != nil && !containsString(knownRecurringIntervals, *recurringInterval) {
+		return newValidationError(fmt.Sprintf("unrecognised recurring_interval %q", *recurringInterval))
+	}
+	if trialDays != nil && *trialDays < 0 {
+		return newValidationError(fmt.Sprintf("trial_days must not be negative, got %d", *trialDays))
+	}
+	return nil
+}
+
+// validateProductAttributes checks a product's image URL, tags, and metadata
+func validateProductAttributes(imageURL *string, tags []string, metadata map[string]interface{}) error {
+	if err := validateImageURL(imageURL); err != nil {
+		return err
+	}
+	if err := validateTags(tags); err != nil {
+		return err
+	}
+	return validateMetadata("metadata", metadata, 1)
+}
+
+// validateOptionalProductAttributes checks the image URL, tags, and metadata that are
+// set in a partial update
+func validateOptionalProductAttributes(imageURL *string, tags *[]string, metadata *map[string]interface{}) error {
+	var tagList []string
+	if tags != nil {
+		tagList = *tags
+	}
+	var metadataMap map[string]interface{}
+	if metadata != nil {
+		metadataMap = *metadata
+	}
+	return validateProductAttributes(imageURL, tagList, metadataMap)
+}
+
+// isAbsoluteURL reports whether s parses as a URL with a scheme and host
+func isAbsoluteURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+// isCountryCode reports whether s looks like an ISO 3166-1 alpha-2 country code
+func isCountryCode(s string) bool {
+	if len(s) != 2 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// isSlug reports whether s is lowercase letters and digits separated by single hyphens
+func isSlug(s string) bool {
+	if s == "" || s[0] == '-' || s[len(s)-1] == '-' || strings.Contains(s, "--") {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// newValidationError creates a client-side validation error
+func newValidationError(message string) error {
+	return NewBagelPayValidationErrorSimple(message, nil)
+}
+
+// containsString reports whether values contains s
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
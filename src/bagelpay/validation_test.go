@@ -0,0 +1,165 @@
+package bagelpay
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPatchProductRequestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		request PatchProductRequest
+		wantErr bool
+	}{
+		{"id only", PatchProductRequest{ProductID: "prod_1"}, false},
+		{"missing id", PatchProductRequest{}, true},
+		{"https image", PatchProductRequest{ProductID: "prod_1", ImageURL: StringPtr("https://cdn.example.com/a.png")}, false},
+		{"http image", PatchProductRequest{ProductID: "prod_1", ImageURL: StringPtr("http://cdn.example.com/a.png")}, true},
+		{"tags", PatchProductRequest{ProductID: "prod_1", Tags: &[]string{"featured"}}, false},
+		{"clear tags", PatchProductRequest{ProductID: "prod_1", Tags: &[]string{}}, false},
+		{"blank tag", PatchProductRequest{ProductID: "prod_1", Tags: &[]string{" "}}, true},
+		{"metadata", PatchProductRequest{ProductID: "prod_1", Metadata: &map[string]interface{}{"crm_id": "C-1"}}, false},
+		{"bad metadata value", PatchProductRequest{ProductID: "prod_1", Metadata: &map[string]interface{}{"flag": true}}, true},
+	}
+	for _, tt := range tests {
+		err := tt.request.Validate()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+		if err != nil && !IsValidationError(err) {
+			t.Errorf("%s: error %T is not a validation error", tt.name, err)
+		}
+	}
+}
+
+func TestPatchProductRequestOmitsUnsetFields(t *testing.T) {
+	data, err := json.Marshal(PatchProductRequest{
+		ProductID: "prod_1",
+		Category:  StringPtr("Courses"),
+		Tags:      &[]string{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"product_id":"prod_1","category":"Courses","tags":[]}`; string(data) != want {
+		t.Errorf("body = %s, want %s", data, want)
+	}
+}
+
+func TestFilterValidateSortAllowlists(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  interface{ Validate() error }
+		wantErr bool
+	}{
+		{"product by price", ProductFilter{SortBy: StringPtr(SortByPrice)}, false},
+		{"product by email", ProductFilter{SortBy: StringPtr(SortByEmail)}, true},
+		{"transaction by amount", TransactionFilter{SortBy: StringPtr(SortByAmount), SortOrder: StringPtr(SortOrderDesc)}, false},
+		{"transaction by name", TransactionFilter{SortBy: StringPtr(SortByName)}, true},
+		{"subscription by created", SubscriptionFilter{SortBy: StringPtr(SortByCreatedAt)}, false},
+		{"subscription by price", SubscriptionFilter{SortBy: StringPtr(SortByPrice)}, true},
+		{"customer by total spend", CustomerFilter{SortBy: StringPtr(SortByTotalSpend)}, false},
+		{"customer by amount", CustomerFilter{SortBy: StringPtr(SortByAmount)}, true},
+		{"bad sort order", CustomerFilter{SortOrder: StringPtr("up")}, true},
+		{"comma in tag", ProductFilter{Tags: []string{"a,b"}}, true},
+	}
+	for _, tt := range tests {
+		err := tt.filter.Validate()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+		if err != nil && !IsValidationError(err) {
+			t.Errorf("%s: error %T is not a validation error", tt.name, err)
+		}
+	}
+}
+
+func TestCheckoutRequestValidateAllowedPaymentMethods(t *testing.T) {
+	tests := []struct {
+		methods []string
+		wantErr bool
+	}{
+		{nil, false},
+		{[]string{"card", "paypal", "wechat_pay"}, false},
+		{[]string{"card", "bitcoin"}, true},
+		{[]string{"Card"}, true},
+	}
+	for _, tt := range tests {
+		err := CheckoutRequest{ProductID: "prod_1", AllowedPaymentMethods: tt.methods}.Validate()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("AllowedPaymentMethods %v: Validate() = %v, want error %v", tt.methods, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateMetadataDepth(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]interface{}
+		wantErr  bool
+	}{
+		{"flat", map[string]interface{}{"crm_id": "C-1", "score": 3}, false},
+		{"one nested level", map[string]interface{}{"crm": map[string]interface{}{"id": "C-1"}}, false},
+		{"two nested levels", map[string]interface{}{"crm": map[string]interface{}{"owner": map[string]interface{}{"id": "U-1"}}}, true},
+		{"nested bad value", map[string]interface{}{"crm": map[string]interface{}{"tags": []string{"a"}}}, true},
+		{"bool", map[string]interface{}{"flag": true}, true},
+	}
+	for _, tt := range tests {
+		err := CreateProductRequest{
+			Name:        "Bagel",
+			Price:       1,
+			Currency:    "USD",
+			BillingType: BillingTypeSinglePayment,
+			Metadata:    tt.metadata,
+		}.Validate()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestClientConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  ClientConfig
+		wantErr bool
+	}{
+		{"minimal", ClientConfig{APIKey: "k"}, false},
+		{"missing api key", ClientConfig{APIKey: " "}, true},
+		{"sub-millisecond timeout", ClientConfig{APIKey: "k", Timeout: time.Microsecond}, true},
+		{"one millisecond timeout", ClientConfig{APIKey: "k", Timeout: time.Millisecond}, false},
+		{"negative retries", ClientConfig{APIKey: "k", Retry: &RetryConfig{MaxRetries: -1}}, true},
+		{"negative body limit", ClientConfig{APIKey: "k", MaxResponseBodyBytes: -1}, true},
+		{"negative pool size", ClientConfig{APIKey: "k", MaxIdleConns: -1}, true},
+		{"bad proxy", ClientConfig{APIKey: "k", ProxyURL: "ftp://proxy.internal"}, true},
+		{"transport options with custom client", ClientConfig{APIKey: "k", HTTPClient: &http.Client{}, DisableHTTP2: true}, true},
+		{"http base url in live mode", ClientConfig{APIKey: "k", BaseURL: "http://api.example.com"}, true},
+		{"http base url in test mode", ClientConfig{APIKey: "k", TestMode: true, BaseURL: "http://localhost:8080"}, false},
+		{"https base url in live mode", ClientConfig{APIKey: "k", BaseURL: "https://api.example.com"}, false},
+	}
+	for _, tt := range tests {
+		err := tt.config.Validate()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+		if err != nil && !IsValidationError(err) {
+			t.Errorf("%s: error %T is not a validation error", tt.name, err)
+		}
+	}
+}
+
+func TestNewClientPanicsOnInvalidConfig(t *testing.T) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("NewClient did not panic")
+		}
+		if msg, _ := r.(string); !strings.Contains(msg, "api key is required") {
+			t.Errorf("panic = %v, want it to name the problem", r)
+		}
+	}()
+	NewClient(ClientConfig{})
+}
@@ -0,0 +1,42 @@
+package bagelpay
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+)
+
+// WebhookSignatureHeader is the HTTP header carrying the webhook signature
+const WebhookSignatureHeader = "X-BagelPay-Signature"
+
+// VerifyWebhookSignature checks that signature is the hex-encoded HMAC-SHA256 of the raw
+// payload keyed with the webhook secret. Pass the request body exactly as received and the
+// value of the X-BagelPay-Signature header. A BagelPayAuthenticationError is returned when
+// the signature does not match.
+//
+// Test vector:
+//
+//	payload:   {"id":"evt_123","type":"checkout.completed"}
+//	secret:    whsec_test
+//	signature: 6dee8a91b746c6d75fab31785b2e4e885d9cd2cf7d4b81d827e36abf3044a4c8
+func VerifyWebhookSignature(payload []byte, signature string, secret string) error {
+	if secret == "" {
+		return NewBagelPayAuthenticationErrorSimple("webhook secret is empty", nil)
+	}
+
+	received, err := hex.DecodeString(strings.TrimSpace(signature))
+	if err != nil || len(received) == 0 {
+		return NewBagelPayAuthenticationErrorSimple("webhook signature is missing or malformed", err)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	expected := mac.Sum(nil)
+
+	if subtle.ConstantTimeCompare(expected, received) != 1 {
+		return NewBagelPayAuthenticationErrorSimple("webhook signature mismatch", nil)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package bagelpay
+
+import (
+	"errors"
+	"testing"
+)
+
+// Test vector published in the README and the VerifyWebhookSignature doc comment
+const (
+	webhookTestPayload   = `{"id":"evt_123","type":"checkout.completed"}`
+	webhookTestSecret    = "whsec_test"
+	webhookTestSignature = "6dee8a91b746c6d75fab31785b2e4e885d9cd2cf7d4b81d827e36abf3044a4c8"
+)
+
+func TestVerifyWebhookSignatureAcceptsTestVector(t *testing.T) {
+	if err := VerifyWebhookSignature([]byte(webhookTestPayload), webhookTestSignature, webhookTestSecret); err != nil {
+		t.Fatalf("VerifyWebhookSignature: %v", err)
+	}
+	// Surrounding whitespace in the header value is tolerated
+	if err := VerifyWebhookSignature([]byte(webhookTestPayload), " "+webhookTestSignature+"\n", webhookTestSecret); err != nil {
+		t.Fatalf("VerifyWebhookSignature with padded signature: %v", err)
+	}
+}
+
+func TestVerifyWebhookSignatureRejects(t *testing.T) {
+	tests := []struct {
+		name      string
+		payload   string
+		signature string
+		secret    string
+	}{
+		{"wrong secret", webhookTestPayload, webhookTestSignature, "whsec_other"},
+		{"tampered payload", `{"id":"evt_123","type":"checkout.failed"}`, webhookTestSignature, webhookTestSecret},
+		{"empty signature", webhookTestPayload, "", webhookTestSecret},
+		{"malformed signature", webhookTestPayload, "not-hex", webhookTestSecret},
+		{"empty secret", webhookTestPayload, webhookTestSignature, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyWebhookSignature([]byte(tt.payload), tt.signature, tt.secret)
+			var authErr *BagelPayAuthenticationError
+			if !errors.As(err, &authErr) {
+				t.Fatalf("error = %v (%T), want *BagelPayAuthenticationError", err, err)
+			}
+		})
+	}
+}
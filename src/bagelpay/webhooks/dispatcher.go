@@ -0,0 +1,95 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/bagelpay/bagelpay-sdk-go/src/bagelpay"
+)
+
+// maxPayloadBytes caps the size of a webhook request body read by WebhookDispatcher
+const maxPayloadBytes = 1 << 20
+
+// WebhookDispatcher is an http.Handler that verifies webhook deliveries and calls the
+// handler registered for each event type. Register handlers before serving requests.
+//
+// Responses tell BagelPay whether to redeliver: 200 when the event was handled or has
+// no handler, 401 for a bad signature, 400 for a malformed payload, and 500 when the
+// handler returns an error, so that the delivery is retried.
+type WebhookDispatcher struct {
+	secret   string
+	mu       sync.RWMutex
+	handlers map[string]func(ctx context.Context, event interface{}) error
+}
+
+// NewWebhookDispatcher creates a dispatcher that verifies deliveries with the webhook secret
+func NewWebhookDispatcher(secret string) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		secret:   secret,
+		handlers: make(map[string]func(ctx context.Context, event interface{}) error),
+	}
+}
+
+// RegisterHandler sets the handler for an event type such as EventCheckoutCompleted,
+// replacing any previous one. The handler receives the typed event ParseEvent returns,
+// or the raw payload as json.RawMessage for types ParseEvent does not decode.
+func (d *WebhookDispatcher) RegisterHandler(eventType string, handler func(ctx context.Context, event interface{}) error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers[eventType] = handler
+}
+
+// ServeHTTP verifies, parses, and dispatches a single webhook delivery
+func (d *WebhookDispatcher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadBytes))
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	signature := r.Header.Get(bagelpay.WebhookSignatureHeader)
+	if err := bagelpay.VerifyWebhookSignature(payload, signature, d.secret); err != nil {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	eventType, err := eventTypeOf(payload)
+	if err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	d.mu.RLock()
+	handler, ok := d.handlers[eventType]
+	d.mu.RUnlock()
+	if !ok {
+		// Acknowledge events nobody listens for so they are not redelivered
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	event, err := ParseEvent(payload)
+	if errors.Is(err, ErrUnknownEventType) {
+		event, err = json.RawMessage(payload), nil
+	}
+	if err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := handler(r.Context(), event); err != nil {
+		http.Error(w, "webhook handler failed", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,148 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bagelpay/bagelpay-sdk-go/src/bagelpay"
+)
+
+const testSecret = "whsec_test"
+
+// sign returns the signature header value BagelPay would send for payload
+func sign(payload string) string {
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliver posts payload to the dispatcher with signature and returns the response
+func deliver(t *testing.T, d *WebhookDispatcher, payload, signature string) *http.Response {
+	t.Helper()
+	server := httptest.NewServer(d)
+	t.Cleanup(server.Close)
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(payload))
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	req.Header.Set(bagelpay.WebhookSignatureHeader, signature)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST: %v", err)
+	}
+	resp.Body.Close()
+	return resp
+}
+
+func TestDispatcherCallsRegisteredHandler(t *testing.T) {
+	payload := `{"id":"evt_1","type":"checkout.completed","data":{"payment_id":"pay_1"}}`
+	var got *CheckoutCompletedEvent
+	d := NewWebhookDispatcher(testSecret)
+	d.RegisterHandler(EventCheckoutCompleted, func(ctx context.Context, event interface{}) error {
+		got = event.(*CheckoutCompletedEvent)
+		return nil
+	})
+
+	resp := deliver(t, d, payload, sign(payload))
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if got == nil {
+		t.Fatal("handler was not called")
+	}
+	if got.ID != "evt_1" || got.Data.PaymentID == nil || *got.Data.PaymentID != "pay_1" {
+		t.Errorf("handler got %+v", got)
+	}
+}
+
+func TestDispatcherPassesUnknownTypesAsRawJSON(t *testing.T) {
+	payload := `{"id":"evt_1","type":"invoice.created"}`
+	var got interface{}
+	d := NewWebhookDispatcher(testSecret)
+	d.RegisterHandler("invoice.created", func(ctx context.Context, event interface{}) error {
+		got = event
+		return nil
+	})
+
+	if resp := deliver(t, d, payload, sign(payload)); resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	raw, ok := got.(json.RawMessage)
+	if !ok || string(raw) != payload {
+		t.Errorf("handler got %T %v, want json.RawMessage of the payload", got, got)
+	}
+}
+
+func TestDispatcherStatusCodes(t *testing.T) {
+	valid := `{"id":"evt_1","type":"checkout.completed"}`
+	oversized := `{"id":"evt_1","type":"checkout.completed","pad":"` + strings.Repeat("x", maxPayloadBytes) + `"}`
+	unparseable := `{"id":`
+
+	tests := []struct {
+		name       string
+		payload    string
+		signature  string
+		handlerErr error
+		want       int
+		wantCalled bool
+	}{
+		{"handled", valid, sign(valid), nil, http.StatusOK, true},
+		{"bad signature", valid, sign(valid + " "), nil, http.StatusUnauthorized, false},
+		{"missing signature", valid, "", nil, http.StatusUnauthorized, false},
+		{"oversized body", oversized, sign(oversized), nil, http.StatusBadRequest, false},
+		{"unparseable body", unparseable, sign(unparseable), nil, http.StatusBadRequest, false},
+		{"handler error", valid, sign(valid), errors.New("database down"), http.StatusInternalServerError, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			d := NewWebhookDispatcher(testSecret)
+			d.RegisterHandler(EventCheckoutCompleted, func(ctx context.Context, event interface{}) error {
+				called = true
+				return tt.handlerErr
+			})
+
+			resp := deliver(t, d, tt.payload, tt.signature)
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+			if called != tt.wantCalled {
+				t.Errorf("handler called = %v, want %v", called, tt.wantCalled)
+			}
+		})
+	}
+}
+
+func TestDispatcherAcknowledgesUnhandledEvents(t *testing.T) {
+	payload := `{"id":"evt_1","type":"refund.created"}`
+	d := NewWebhookDispatcher(testSecret)
+	if resp := deliver(t, d, payload, sign(payload)); resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestDispatcherRejectsNonPost(t *testing.T) {
+	server := httptest.NewServer(NewWebhookDispatcher(testSecret))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want 405", resp.StatusCode)
+	}
+	if allow := resp.Header.Get("Allow"); allow != http.MethodPost {
+		t.Errorf("Allow = %q, want POST", allow)
+	}
+}
@@ -0,0 +1,199 @@
+/*
+Package webhooks provides typed BagelPay webhook events and an HTTP handler that
+dispatches them.
+
+Example usage:
+
+	event, err := webhooks.ParseEvent(payload)
+	if err != nil {
+		return err
+	}
+
+	switch e := event.(type) {
+	case *webhooks.CheckoutCompletedEvent:
+		fmt.Println("Paid:", *e.Data.PaymentID)
+	case *webhooks.SubscriptionCancelledEvent:
+		fmt.Println("Cancelled:", *e.Data.SubscriptionID)
+	}
+
+WebhookDispatcher wraps verification, parsing, and dispatch in an http.Handler:
+
+	dispatcher := webhooks.NewWebhookDispatcher(secret)
+	dispatcher.RegisterHandler(webhooks.EventCheckoutCompleted, func(ctx context.Context, event interface{}) error {
+		return fulfil(ctx, event.(*webhooks.CheckoutCompletedEvent))
+	})
+	http.Handle("/webhooks/bagelpay", dispatcher)
+*/
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/bagelpay/bagelpay-sdk-go/src/bagelpay"
+)
+
+// Webhook event types
+const (
+	EventCheckoutCompleted     = "checkout.completed"
+	EventPaymentFailed         = "checkout.failed"
+	EventCheckoutCancelled     = "checkout.cancel"
+	EventSubscriptionCreated   = "subscription.created"
+	EventSubscriptionTrialing  = "subscription.trialing"
+	EventSubscriptionPaid      = "subscription.paid"
+	EventSubscriptionCancelled = "subscription.canceled"
+	EventRefundIssued          = "refund.created"
+	EventCustomerCreated       = "customer.created"
+)
+
+// ErrUnknownEventType is wrapped by the error ParseEvent returns for unsupported event types
+var ErrUnknownEventType = errors.New("unknown webhook event type")
+
+// BaseEvent holds the fields shared by every webhook event
+type BaseEvent struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	CreatedAt string `json:"created_at"`
+	Mode      string `json:"mode"`
+}
+
+// CheckoutCompletedEvent is sent when a checkout session is paid
+type CheckoutCompletedEvent struct {
+	BaseEvent
+	Data bagelpay.CheckoutResponse `json:"data"`
+}
+
+// PaymentFailedEvent is sent when a checkout payment fails
+type PaymentFailedEvent struct {
+	BaseEvent
+	Data bagelpay.CheckoutResponse `json:"data"`
+}
+
+// CheckoutCancelledEvent is sent when a checkout session is cancelled
+type CheckoutCancelledEvent struct {
+	BaseEvent
+	Data bagelpay.CheckoutResponse `json:"data"`
+}
+
+// SubscriptionCreatedEvent is sent when a subscription starts
+type SubscriptionCreatedEvent struct {
+	BaseEvent
+	Data bagelpay.Subscription `json:"data"`
+}
+
+// SubscriptionTrialingEvent is sent when a subscription enters its trial period
+type SubscriptionTrialingEvent struct {
+	BaseEvent
+	Data bagelpay.Subscription `json:"data"`
+}
+
+// SubscriptionPaidEvent is sent when a subscription billing period is paid
+type SubscriptionPaidEvent struct {
+	BaseEvent
+	Data bagelpay.Subscription `json:"data"`
+}
+
+// SubscriptionCancelledEvent is sent when a subscription is cancelled
+type SubscriptionCancelledEvent struct {
+	BaseEvent
+	Data bagelpay.Subscription `json:"data"`
+}
+
+// RefundIssuedEvent is sent when a refund is created
+type RefundIssuedEvent struct {
+	BaseEvent
+	Data bagelpay.Refund `json:"data"`
+}
+
+// CustomerCreatedEvent is sent when a customer is created
+type CustomerCreatedEvent struct {
+	BaseEvent
+	Data bagelpay.CustomerData `json:"data"`
+}
+
+// ParseEvent decodes a webhook payload into the typed event matching its type field.
+// The result is one of *CheckoutCompletedEvent, *PaymentFailedEvent,
+// *CheckoutCancelledEvent, *SubscriptionCreatedEvent, *SubscriptionTrialingEvent,
+// *SubscriptionPaidEvent, *SubscriptionCancelledEvent, *RefundIssuedEvent, or
+// *CustomerCreatedEvent. Unsupported types return an error wrapping ErrUnknownEventType.
+func ParseEvent(payload []byte) (interface{}, error) {
+	eventType, err := eventTypeOf(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var event interface{}
+	switch eventType {
+	case EventCheckoutCompleted:
+		event = &CheckoutCompletedEvent{}
+	case EventPaymentFailed:
+		event = &PaymentFailedEvent{}
+	case EventCheckoutCancelled:
+		event = &CheckoutCancelledEvent{}
+	case EventSubscriptionCreated:
+		event = &SubscriptionCreatedEvent{}
+	case EventSubscriptionTrialing:
+		event = &SubscriptionTrialingEvent{}
+	case EventSubscriptionPaid:
+		event = &SubscriptionPaidEvent{}
+	case EventSubscriptionCancelled:
+		event = &SubscriptionCancelledEvent{}
+	case EventRefundIssued:
+		event = &RefundIssuedEvent{}
+	case EventCustomerCreated:
+		event = &CustomerCreatedEvent{}
+	default:
+		return nil, bagelpay.NewBagelPayError(fmt.Sprintf("unsupported webhook event type %q", eventType), ErrUnknownEventType)
+	}
+
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, bagelpay.NewBagelPayError("failed to parse webhook payload", err)
+	}
+	if base := baseOf(event); base != nil && base.Type == "" {
+		base.Type = eventType
+	}
+	return event, nil
+}
+
+// eventTypeOf reads the event type from a webhook payload
+func eventTypeOf(payload []byte) (string, error) {
+	var header struct {
+		Type      string `json:"type"`
+		EventType string `json:"event_type"`
+	}
+	if err := json.Unmarshal(payload, &header); err != nil {
+		return "", bagelpay.NewBagelPayError("failed to parse webhook payload", err)
+	}
+
+	// Older payloads name the field event_type
+	if header.Type == "" {
+		return header.EventType, nil
+	}
+	return header.Type, nil
+}
+
+// baseOf returns the BaseEvent embedded in a typed event
+func baseOf(event interface{}) *BaseEvent {
+	switch e := event.(type) {
+	case *CheckoutCompletedEvent:
+		return &e.BaseEvent
+	case *PaymentFailedEvent:
+		return &e.BaseEvent
+	case *CheckoutCancelledEvent:
+		return &e.BaseEvent
+	case *SubscriptionCreatedEvent:
+		return &e.BaseEvent
+	case *SubscriptionTrialingEvent:
+		return &e.BaseEvent
+	case *SubscriptionPaidEvent:
+		return &e.BaseEvent
+	case *SubscriptionCancelledEvent:
+		return &e.BaseEvent
+	case *RefundIssuedEvent:
+		return &e.BaseEvent
+	case *CustomerCreatedEvent:
+		return &e.BaseEvent
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package webhooks
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  string
+		wantType interface{}
+		wantID   string
+	}{
+		{"checkout completed", `{"id":"evt_1","type":"checkout.completed","data":{"payment_id":"pay_1"}}`, &CheckoutCompletedEvent{}, "evt_1"},
+		{"payment failed", `{"id":"evt_2","type":"checkout.failed"}`, &PaymentFailedEvent{}, "evt_2"},
+		{"checkout cancelled", `{"id":"evt_3","type":"checkout.cancel"}`, &CheckoutCancelledEvent{}, "evt_3"},
+		{"subscription created", `{"id":"evt_4","type":"subscription.created"}`, &SubscriptionCreatedEvent{}, "evt_4"},
+		{"subscription trialing", `{"id":"evt_5","type":"subscription.trialing"}`, &SubscriptionTrialingEvent{}, "evt_5"},
+		{"subscription paid", `{"id":"evt_6","type":"subscription.paid"}`, &SubscriptionPaidEvent{}, "evt_6"},
+		{"subscription cancelled", `{"id":"evt_7","type":"subscription.canceled"}`, &SubscriptionCancelledEvent{}, "evt_7"},
+		{"refund issued", `{"id":"evt_8","type":"refund.created"}`, &RefundIssuedEvent{}, "evt_8"},
+		{"customer created", `{"id":"evt_9","type":"customer.created"}`, &CustomerCreatedEvent{}, "evt_9"},
+		{"event_type fallback", `{"id":"evt_10","event_type":"checkout.completed"}`, &CheckoutCompletedEvent{}, "evt_10"},
+		{"type wins over event_type", `{"id":"evt_11","type":"refund.created","event_type":"checkout.completed"}`, &RefundIssuedEvent{}, "evt_11"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := ParseEvent([]byte(tt.payload))
+			if err != nil {
+				t.Fatalf("ParseEvent: %v", err)
+			}
+			if reflect.TypeOf(event) != reflect.TypeOf(tt.wantType) {
+				t.Fatalf("event = %T, want %T", event, tt.wantType)
+			}
+			base := baseOf(event)
+			if base.ID != tt.wantID {
+				t.Errorf("ID = %q, want %q", base.ID, tt.wantID)
+			}
+			if base.Type == "" {
+				t.Error("Type was not filled in")
+			}
+		})
+	}
+}
+
+func TestParseEventFallbackFillsType(t *testing.T) {
+	event, err := ParseEvent([]byte(`{"id":"evt_1","event_type":"checkout.completed","data":{"payment_id":"pay_1"}}`))
+	if err != nil {
+		t.Fatalf("ParseEvent: %v", err)
+	}
+	e := event.(*CheckoutCompletedEvent)
+	if e.Type != EventCheckoutCompleted {
+		t.Errorf("Type = %q, want %q", e.Type, EventCheckoutCompleted)
+	}
+	if e.Data.PaymentID == nil || *e.Data.PaymentID != "pay_1" {
+		t.Errorf("Data.PaymentID = %v, want pay_1", e.Data.PaymentID)
+	}
+}
+
+func TestParseEventErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		payload     string
+		wantUnknown bool
+	}{
+		{"unknown type", `{"id":"evt_1","type":"invoice.created"}`, true},
+		{"missing type", `{"id":"evt_1"}`, true},
+		{"invalid JSON", `{"id":`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseEvent([]byte(tt.payload))
+			if err == nil {
+				t.Fatal("ParseEvent succeeded, want error")
+			}
+			if got := errors.Is(err, ErrUnknownEventType); got != tt.wantUnknown {
+				t.Errorf("errors.Is(err, ErrUnknownEventType) = %v, want %v (err: %v)", got, tt.wantUnknown, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+// Package bagelpaymetrics provides Prometheus instrumentation for the BagelPay client.
+//
+// The package is only populated when built with the "prometheus" build tag, so
+// that prometheus/client_golang is not a dependency for users who don't need it:
+//
+//	go build -tags prometheus ./...
+//
+// Example usage:
+//
+//	client := bagelpay.NewClient(bagelpay.ClientConfig{
+//		APIKey: "your-api-key",
+//		Middlewares: []func(http.RoundTripper) http.RoundTripper{
+//			bagelpaymetrics.PrometheusMiddleware(prometheus.DefaultRegisterer),
+//		},
+//	})
+package bagelpaymetrics
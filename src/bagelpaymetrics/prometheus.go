@@ -0,0 +1,82 @@
+//go:build prometheus
+
+package bagelpaymetrics
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bagelpay/bagelpay-sdk-go/src/bagelpay"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusMiddleware returns a client middleware that records request counts, latencies,
+// and retries with the given registerer. Metrics that are already registered, for example by
+// a second client sharing the registerer, are reused.
+func PrometheusMiddleware(registerer prometheus.Registerer) func(http.RoundTripper) http.RoundTripper {
+	requests := register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "bagelpay_requests_total",
+		Help: "Total number of BagelPay API requests.",
+	}, []string{"method", "endpoint", "status_code"}))
+	duration := register(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "bagelpay_request_duration_seconds",
+		Help:    "Latency of BagelPay API requests in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "endpoint"}))
+	retries := register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "bagelpay_retry_total",
+		Help: "Total number of retried BagelPay API requests.",
+	}, []string{"endpoint"}))
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return bagelpay.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			endpoint := endpointLabel(req.URL.Path)
+			if bagelpay.RetryAttempt(req.Context()) > 0 {
+				retries.WithLabelValues(endpoint).Inc()
+			}
+
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			duration.WithLabelValues(req.Method, endpoint).Observe(time.Since(start).Seconds())
+
+			// Transport failures have no status code
+			statusCode := "error"
+			if err == nil {
+				statusCode = strconv.Itoa(resp.StatusCode)
+			}
+			requests.WithLabelValues(req.Method, endpoint, statusCode).Inc()
+			return resp, err
+		})
+	}
+}
+
+// register registers the collector, returning the existing one if it was registered before
+func register[T prometheus.Collector](registerer prometheus.Registerer, collector T) T {
+	if err := registerer.Register(collector); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			if existing, ok := are.ExistingCollector.(T); ok {
+				return existing
+			}
+		}
+		panic(err)
+	}
+	return collector
+}
+
+// staticSegment matches path segments that name a resource or action rather than an ID
+var staticSegment = regexp.MustCompile(`^[a-z]+(-[a-z]+)*$`)
+
+// endpointLabel replaces the IDs in a request path with ":id" to keep label cardinality
+// bounded, e.g. /api/products/prod_123/archive becomes /api/products/:id/archive
+func endpointLabel(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if segment != "" && !staticSegment.MatchString(segment) {
+			segments[i] = ":id"
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,199 @@
+//go:build prometheus
+
+package bagelpaymetrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bagelpay/bagelpay-sdk-go/src/bagelpay"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// sample is one gathered metric: its labels and its counter value or histogram count
+type sample struct {
+	labels map[string]string
+	value  float64
+}
+
+// gather returns the samples in registry by metric name
+func gather(t *testing.T, registry *prometheus.Registry) map[string][]sample {
+	t.Helper()
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+
+	samples := make(map[string][]sample)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			s := sample{labels: make(map[string]string)}
+			for _, label := range metric.GetLabel() {
+				s.labels[label.GetName()] = label.GetValue()
+			}
+			if metric.GetHistogram() != nil {
+				s.value = float64(metric.GetHistogram().GetSampleCount())
+			} else {
+				s.value = metric.GetCounter().GetValue()
+			}
+			samples[family.GetName()] = append(samples[family.GetName()], s)
+		}
+	}
+	return samples
+}
+
+// newInstrumentedClient returns a client for handler that records metrics in registry
+func newInstrumentedClient(t *testing.T, registry prometheus.Registerer, handler http.HandlerFunc) *bagelpay.BagelPayClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return bagelpay.NewClient(bagelpay.ClientConfig{
+		APIKey:      "bagel_test_key_1234",
+		BaseURL:     server.URL,
+		TestMode:    true,
+		Retry:       &bagelpay.RetryConfig{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond},
+		Middlewares: []func(http.RoundTripper) http.RoundTripper{PrometheusMiddleware(registry)},
+	})
+}
+
+func TestPrometheusMiddleware(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	var archiveCalls int32
+	client := newInstrumentedClient(t, registry, func(w http.ResponseWriter, r *http.Request) {
+		// The first archive request is rate limited and retried
+		if r.URL.Path == "/api/products/prod_123/archive" && atomic.AddInt32(&archiveCalls, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"message":"slow down"}`))
+			return
+		}
+		w.Write([]byte(`{"data":{}}`))
+	})
+
+	if _, err := client.GetStoreInfo(context.Background()); err != nil {
+		t.Fatalf("GetStoreInfo: %v", err)
+	}
+	if _, err := client.ArchiveProduct(context.Background(), "prod_123"); err != nil {
+		t.Fatalf("ArchiveProduct: %v", err)
+	}
+
+	const archive = "/api/products/:id/archive"
+	samples := gather(t, registry)
+	want := map[string][]sample{
+		"bagelpay_requests_total": {
+			{map[string]string{"method": "POST", "endpoint": archive, "status_code": "200"}, 1},
+			{map[string]string{"method": "POST", "endpoint": archive, "status_code": "429"}, 1},
+			{map[string]string{"method": "GET", "endpoint": "/api/store", "status_code": "200"}, 1},
+		},
+		"bagelpay_request_duration_seconds": {
+			{map[string]string{"method": "POST", "endpoint": archive}, 2},
+			{map[string]string{"method": "GET", "endpoint": "/api/store"}, 1},
+		},
+		"bagelpay_retry_total": {
+			{map[string]string{"endpoint": archive}, 1},
+		},
+	}
+	for name, wantSamples := range want {
+		if !sameSamples(samples[name], wantSamples) {
+			t.Errorf("%s = %v, want %v", name, samples[name], wantSamples)
+		}
+	}
+	if len(samples) != len(want) {
+		t.Errorf("gathered %d metrics, want %d: %v", len(samples), len(want), samples)
+	}
+}
+
+func TestPrometheusMiddlewareTransportError(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	client := bagelpay.NewClient(bagelpay.ClientConfig{
+		APIKey:      "bagel_test_key_1234",
+		BaseURL:     server.URL,
+		TestMode:    true,
+		Middlewares: []func(http.RoundTripper) http.RoundTripper{PrometheusMiddleware(registry)},
+	})
+	if _, err := client.GetStoreInfo(context.Background()); err == nil {
+		t.Fatal("GetStoreInfo succeeded against a closed server")
+	}
+
+	samples := gather(t, registry)["bagelpay_requests_total"]
+	want := []sample{{map[string]string{"method": "GET", "endpoint": "/api/store", "status_code": "error"}, 1}}
+	if !sameSamples(samples, want) {
+		t.Errorf("bagelpay_requests_total = %v, want %v", samples, want)
+	}
+}
+
+func TestPrometheusMiddlewareSharedRegisterer(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{}}`))
+	}
+	first := newInstrumentedClient(t, registry, handler)
+
+	var second *bagelpay.BagelPayClient
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Fatalf("second PrometheusMiddleware on the same registerer panicked: %v", r)
+			}
+		}()
+		second = newInstrumentedClient(t, registry, handler)
+	}()
+
+	for _, client := range []*bagelpay.BagelPayClient{first, second} {
+		if _, err := client.GetStoreInfo(context.Background()); err != nil {
+			t.Fatalf("GetStoreInfo: %v", err)
+		}
+	}
+	samples := gather(t, registry)["bagelpay_requests_total"]
+	want := []sample{{map[string]string{"method": "GET", "endpoint": "/api/store", "status_code": "200"}, 2}}
+	if !sameSamples(samples, want) {
+		t.Errorf("bagelpay_requests_total = %v, want both clients counted in %v", samples, want)
+	}
+}
+
+func TestEndpointLabel(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/api/store", "/api/store"},
+		{"/api/products/prod_123/archive", "/api/products/:id/archive"},
+		{"/api/products/prod_123", "/api/products/:id"},
+		{"/api/payment-links/link_9", "/api/payment-links/:id"},
+		{"/api/coupons/SUMMER20/expire", "/api/coupons/:id/expire"},
+		{"/api/customers/42/subscriptions", "/api/customers/:id/subscriptions"},
+	}
+	for _, tt := range tests {
+		if got := endpointLabel(tt.path); got != tt.want {
+			t.Errorf("endpointLabel(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+// sameSamples reports whether got and want hold the same samples in any order
+func sameSamples(got, want []sample) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	used := make([]bool, len(got))
+	for _, w := range want {
+		found := false
+		for i, g := range got {
+			if !used[i] && g.value == w.value && reflect.DeepEqual(g.labels, w.labels) {
+				used[i], found = true, true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
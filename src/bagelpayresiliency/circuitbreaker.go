@@ -0,0 +1,129 @@
+package bagelpayresiliency
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bagelpay/bagelpay-sdk-go/src/bagelpay"
+)
+
+// circuitState is the state of a circuit breaker
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker tracks consecutive failures shared by every request through one middleware
+type circuitBreaker struct {
+	threshold int
+	timeout   time.Duration
+
+	mu       sync.Mutex
+	state    circuitState
+	failures int
+	openedAt time.Time
+}
+
+// CircuitBreakerMiddleware returns a client middleware that stops calling the API while it
+// is failing. After threshold consecutive failures, meaning transport errors or 5xx
+// responses, the circuit opens and requests fail immediately with a BagelPayServerError
+// whose message is "circuit open". Once timeout has passed a single probe request is let
+// through: its success closes the circuit and its failure opens it for another timeout.
+//
+// Clients built with the same middleware value share one circuit.
+// It panics if threshold is less than 1.
+func CircuitBreakerMiddleware(threshold int, timeout time.Duration) func(http.RoundTripper) http.RoundTripper {
+	if threshold < 1 {
+		panic("bagelpayresiliency: circuit breaker threshold must be at least 1")
+	}
+	breaker := &circuitBreaker{threshold: threshold, timeout: timeout}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return bagelpay.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			ok, probe := breaker.allow()
+			if !ok {
+				return nil, bagelpay.NewBagelPayServerErrorSimple(http.StatusServiceUnavailable, "circuit open", nil)
+			}
+
+			resp, err := next.RoundTrip(req)
+			switch {
+			case err != nil && errors.Is(req.Context().Err(), context.Canceled):
+				// The caller gave up; that says nothing about the API's health
+				breaker.release(probe)
+			case err != nil || resp.StatusCode >= 500:
+				breaker.recordFailure(probe)
+			default:
+				breaker.recordSuccess(probe)
+			}
+			return resp, err
+		})
+	}
+}
+
+// allow reports whether a request may be sent, moving an open circuit whose timeout has
+// passed to half-open and admitting that request as its probe
+func (b *circuitBreaker) allow() (ok, probe bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if time.Since(b.openedAt) < b.timeout {
+			return false, false
+		}
+		b.state = circuitHalfOpen
+		return true, true
+	case circuitHalfOpen:
+		// A probe is already in flight
+		return false, false
+	default:
+		return true, false
+	}
+}
+
+// recordFailure opens the circuit when the probe fails, or counts a failure while the
+// circuit is closed and opens it at the threshold. Requests sent before the circuit
+// opened no longer affect it.
+func (b *circuitBreaker) recordFailure(probe bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !probe && b.state != circuitClosed {
+		return
+	}
+	b.failures++
+	if probe || b.failures >= b.threshold {
+		b.state = circuitOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// recordSuccess resets the failure count, closing the circuit when the probe succeeds.
+// Requests sent before the circuit opened no longer affect it.
+func (b *circuitBreaker) recordSuccess(probe bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !probe && b.state != circuitClosed {
+		return
+	}
+	b.state = circuitClosed
+	b.failures = 0
+}
+
+// release lets another request probe the circuit when the probe ended without a verdict
+func (b *circuitBreaker) release(probe bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if probe && b.state == circuitHalfOpen {
+		b.state = circuitOpen
+		b.openedAt = time.Time{}
+	}
+}
@@ -0,0 +1,213 @@
+package bagelpayresiliency
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bagelpay/bagelpay-sdk-go/src/bagelpay"
+)
+
+const testTimeout = 20 * time.Millisecond
+
+// fakeAPI answers each request with the status in its X-Status header, or 200. A
+// request whose X-Block header names a channel in blocks waits for that channel first.
+type fakeAPI struct {
+	calls  int32
+	blocks map[string]chan struct{}
+}
+
+func (f *fakeAPI) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&f.calls, 1)
+	if block, ok := f.blocks[req.Header.Get("X-Block")]; ok {
+		select {
+		case <-block:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+	status := http.StatusOK
+	if req.Header.Get("X-Status") == "500" {
+		status = http.StatusInternalServerError
+	}
+	return &http.Response{StatusCode: status, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("{}")), Request: req}, nil
+}
+
+// send makes a request through transport with the given status and block headers
+func send(ctx context.Context, transport http.RoundTripper, status, block string) (*http.Response, error) {
+	req, _ := http.NewRequestWithContext(ctx, "GET", "https://test.bagelpay.io/api/store", nil)
+	req.Header.Set("X-Status", status)
+	req.Header.Set("X-Block", block)
+	return transport.RoundTrip(req)
+}
+
+// isCircuitOpen reports whether err is the error returned while the circuit is open
+func isCircuitOpen(err error) bool {
+	var serverErr *bagelpay.BagelPayServerError
+	return errors.As(err, &serverErr) && strings.Contains(err.Error(), "circuit open")
+}
+
+// openCircuit fails threshold requests through transport
+func openCircuit(t *testing.T, transport http.RoundTripper, threshold int) {
+	t.Helper()
+	for i := 0; i < threshold; i++ {
+		if _, err := send(context.Background(), transport, "500", ""); err != nil {
+			t.Fatalf("failure %d: %v", i+1, err)
+		}
+	}
+}
+
+func newBreaker(threshold int) (*fakeAPI, http.RoundTripper) {
+	api := &fakeAPI{blocks: map[string]chan struct{}{}}
+	return api, CircuitBreakerMiddleware(threshold, testTimeout)(api)
+}
+
+func TestCircuitOpensAfterThreshold(t *testing.T) {
+	api, transport := newBreaker(3)
+
+	// A success resets the count, so only consecutive failures open the circuit
+	openCircuit(t, transport, 2)
+	send(context.Background(), transport, "", "")
+	openCircuit(t, transport, 2)
+	if _, err := send(context.Background(), transport, "", ""); err != nil {
+		t.Fatalf("circuit opened before the threshold: %v", err)
+	}
+
+	openCircuit(t, transport, 3)
+	calls := atomic.LoadInt32(&api.calls)
+	_, err := send(context.Background(), transport, "", "")
+	if !isCircuitOpen(err) {
+		t.Fatalf("error = %v, want circuit open", err)
+	}
+	if atomic.LoadInt32(&api.calls) != calls {
+		t.Error("request reached the API while the circuit was open")
+	}
+}
+
+func TestCircuitAdmitsOneProbe(t *testing.T) {
+	api, transport := newBreaker(1)
+	api.blocks["probe"] = make(chan struct{})
+	openCircuit(t, transport, 1)
+	time.Sleep(testTimeout)
+
+	done := make(chan error)
+	go func() {
+		_, err := send(context.Background(), transport, "", "probe")
+		done <- err
+	}()
+	waitForCalls(t, api, 2)
+
+	if _, err := send(context.Background(), transport, "", ""); !isCircuitOpen(err) {
+		t.Errorf("second request during the probe: error = %v, want circuit open", err)
+	}
+
+	close(api.blocks["probe"])
+	if err := <-done; err != nil {
+		t.Fatalf("probe: %v", err)
+	}
+	if _, err := send(context.Background(), transport, "", ""); err != nil {
+		t.Errorf("request after a successful probe: %v", err)
+	}
+}
+
+func TestCircuitFailedProbeReopens(t *testing.T) {
+	_, transport := newBreaker(1)
+	openCircuit(t, transport, 1)
+	time.Sleep(testTimeout)
+
+	if _, err := send(context.Background(), transport, "500", ""); err != nil {
+		t.Fatalf("probe: %v", err)
+	}
+	if _, err := send(context.Background(), transport, "", ""); !isCircuitOpen(err) {
+		t.Fatalf("error = %v, want circuit open after a failed probe", err)
+	}
+
+	time.Sleep(testTimeout)
+	if _, err := send(context.Background(), transport, "", ""); err != nil {
+		t.Errorf("probe after the second timeout: %v", err)
+	}
+}
+
+func TestCircuitCancelledProbeReleasesSlot(t *testing.T) {
+	api, transport := newBreaker(1)
+	api.blocks["probe"] = make(chan struct{})
+	openCircuit(t, transport, 1)
+	time.Sleep(testTimeout)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(5*time.Millisecond, cancel)
+	if _, err := send(ctx, transport, "", "probe"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("probe error = %v, want context.Canceled", err)
+	}
+
+	if _, err := send(context.Background(), transport, "", ""); err != nil {
+		t.Errorf("request after a cancelled probe: %v, want it admitted as the next probe", err)
+	}
+}
+
+func TestCircuitIgnoresRequestsFromBeforeItOpened(t *testing.T) {
+	api, transport := newBreaker(1)
+	api.blocks["slow"] = make(chan struct{})
+	api.blocks["probe"] = make(chan struct{})
+
+	// A slow request starts while the circuit is closed
+	slow := make(chan error)
+	go func() {
+		_, err := send(context.Background(), transport, "", "slow")
+		slow <- err
+	}()
+	waitForCalls(t, api, 1)
+
+	openCircuit(t, transport, 1)
+	time.Sleep(testTimeout)
+	probe := make(chan error)
+	go func() {
+		_, err := send(context.Background(), transport, "500", "probe")
+		probe <- err
+	}()
+	waitForCalls(t, api, 3)
+
+	// The slow request succeeding must not close the half-open circuit
+	close(api.blocks["slow"])
+	if err := <-slow; err != nil {
+		t.Fatalf("slow request: %v", err)
+	}
+	if _, err := send(context.Background(), transport, "", ""); !isCircuitOpen(err) {
+		t.Fatalf("error = %v, want circuit open while the probe is in flight", err)
+	}
+
+	// The probe's failure is what decides
+	close(api.blocks["probe"])
+	if err := <-probe; err != nil {
+		t.Fatalf("probe: %v", err)
+	}
+	if _, err := send(context.Background(), transport, "", ""); !isCircuitOpen(err) {
+		t.Errorf("error = %v, want circuit open after the probe failed", err)
+	}
+}
+
+func TestCircuitBreakerPanicsOnZeroThreshold(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("CircuitBreakerMiddleware(0, ...) did not panic")
+		}
+	}()
+	CircuitBreakerMiddleware(0, time.Second)
+}
+
+// waitForCalls waits until the API has seen n requests
+func waitForCalls(t *testing.T, api *fakeAPI, n int32) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for atomic.LoadInt32(&api.calls) < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("API saw %d requests, want %d", atomic.LoadInt32(&api.calls), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
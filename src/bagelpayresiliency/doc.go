@@ -0,0 +1,12 @@
+// Package bagelpayresiliency provides client middlewares that protect applications
+// from BagelPay API outages.
+//
+// Example usage:
+//
+//	client := bagelpay.NewClient(bagelpay.ClientConfig{
+//		APIKey: "your-api-key",
+//		Middlewares: []func(http.RoundTripper) http.RoundTripper{
+//			bagelpayresiliency.CircuitBreakerMiddleware(5, 30*time.Second),
+//		},
+//	})
+package bagelpayresiliency
@@ -0,0 +1,397 @@
+package bagelpaytest
+
+import (
+	"context"
+
+	"github.com/bagelpay/bagelpay-sdk-go/src/bagelpay"
+)
+
+// Ping returns the fixture configured with On("Ping")
+func (m *MockBagelPayClient) Ping(ctx context.Context) error {
+	return errorResult(m, "Ping")
+}
+
+// ValidateAPIKey returns the fixture configured with On("ValidateAPIKey")
+func (m *MockBagelPayClient) ValidateAPIKey(ctx context.Context) (*bagelpay.APIKeyInfo, error) {
+	return result[*bagelpay.APIKeyInfo](m, "ValidateAPIKey")
+}
+
+// CreateCheckout returns the fixture configured with On("CreateCheckout")
+func (m *MockBagelPayClient) CreateCheckout(ctx context.Context, request bagelpay.CheckoutRequest) (*bagelpay.CheckoutResponse, error) {
+	return result[*bagelpay.CheckoutResponse](m, "CreateCheckout")
+}
+
+// CreateCartCheckout returns the fixture configured with On("CreateCartCheckout")
+func (m *MockBagelPayClient) CreateCartCheckout(ctx context.Context, request bagelpay.CartCheckoutRequest) (*bagelpay.CheckoutResponse, error) {
+	return result[*bagelpay.CheckoutResponse](m, "CreateCartCheckout")
+}
+
+// GetCheckout returns the fixture configured with On("GetCheckout")
+func (m *MockBagelPayClient) GetCheckout(ctx context.Context, paymentID string) (*bagelpay.CheckoutResponse, error) {
+	return result[*bagelpay.CheckoutResponse](m, "GetCheckout")
+}
+
+// CancelCheckout returns the fixture configured with On("CancelCheckout")
+func (m *MockBagelPayClient) CancelCheckout(ctx context.Context, paymentID string) (*bagelpay.CheckoutResponse, error) {
+	return result[*bagelpay.CheckoutResponse](m, "CancelCheckout")
+}
+
+// ListCheckouts returns the fixture configured with On("ListCheckouts")
+func (m *MockBagelPayClient) ListCheckouts(ctx context.Context, filter bagelpay.CheckoutFilter, pageNum, pageSize int) (*bagelpay.CheckoutListResponse, error) {
+	return result[*bagelpay.CheckoutListResponse](m, "ListCheckouts")
+}
+
+// ListSupportedLocales returns the fixture configured with On("ListSupportedLocales")
+func (m *MockBagelPayClient) ListSupportedLocales(ctx context.Context) ([]string, error) {
+	return result[[]string](m, "ListSupportedLocales")
+}
+
+// CreateCoupon returns the fixture configured with On("CreateCoupon")
+func (m *MockBagelPayClient) CreateCoupon(ctx context.Context, request bagelpay.CouponRequest) (*bagelpay.Coupon, error) {
+	return result[*bagelpay.Coupon](m, "CreateCoupon")
+}
+
+// GetCoupon returns the fixture configured with On("GetCoupon")
+func (m *MockBagelPayClient) GetCoupon(ctx context.Context, code string) (*bagelpay.Coupon, error) {
+	return result[*bagelpay.Coupon](m, "GetCoupon")
+}
+
+// ListCoupons returns the fixture configured with On("ListCoupons")
+func (m *MockBagelPayClient) ListCoupons(ctx context.Context, pageNum, pageSize int) (*bagelpay.CouponListResponse, error) {
+	return result[*bagelpay.CouponListResponse](m, "ListCoupons")
+}
+
+// ExpireCoupon returns the fixture configured with On("ExpireCoupon")
+func (m *MockBagelPayClient) ExpireCoupon(ctx context.Context, code string) error {
+	return errorResult(m, "ExpireCoupon")
+}
+
+// GeneratePaymentLink returns the fixture configured with On("GeneratePaymentLink")
+func (m *MockBagelPayClient) GeneratePaymentLink(ctx context.Context, productID string, opts bagelpay.PaymentLinkOptions) (*bagelpay.PaymentLink, error) {
+	return result[*bagelpay.PaymentLink](m, "GeneratePaymentLink")
+}
+
+// GetPaymentLink returns the fixture configured with On("GetPaymentLink")
+func (m *MockBagelPayClient) GetPaymentLink(ctx context.Context, linkID string) (*bagelpay.PaymentLink, error) {
+	return result[*bagelpay.PaymentLink](m, "GetPaymentLink")
+}
+
+// ListPaymentLinks returns the fixture configured with On("ListPaymentLinks")
+func (m *MockBagelPayClient) ListPaymentLinks(ctx context.Context, pageNum, pageSize int) (*bagelpay.PaymentLinkListResponse, error) {
+	return result[*bagelpay.PaymentLinkListResponse](m, "ListPaymentLinks")
+}
+
+// DeletePaymentLink returns the fixture configured with On("DeletePaymentLink")
+func (m *MockBagelPayClient) DeletePaymentLink(ctx context.Context, linkID string) error {
+	return errorResult(m, "DeletePaymentLink")
+}
+
+// CreateProduct returns the fixture configured with On("CreateProduct")
+func (m *MockBagelPayClient) CreateProduct(ctx context.Context, request bagelpay.CreateProductRequest) (*bagelpay.Product, error) {
+	return result[*bagelpay.Product](m, "CreateProduct")
+}
+
+// GetProduct returns the fixture configured with On("GetProduct")
+func (m *MockBagelPayClient) GetProduct(ctx context.Context, productID string) (*bagelpay.Product, error) {
+	return result[*bagelpay.Product](m, "GetProduct")
+}
+
+// ListProducts returns the fixture configured with On("ListProducts")
+func (m *MockBagelPayClient) ListProducts(ctx context.Context, filter bagelpay.ProductFilter, pageNum, pageSize int) (*bagelpay.ProductListResponse, error) {
+	return result[*bagelpay.ProductListResponse](m, "ListProducts")
+}
+
+// SearchProducts returns the fixture configured with On("SearchProducts")
+func (m *MockBagelPayClient) SearchProducts(ctx context.Context, query string, pageNum, pageSize int) (*bagelpay.ProductListResponse, error) {
+	return result[*bagelpay.ProductListResponse](m, "SearchProducts")
+}
+
+// ListProductCategories returns the fixture configured with On("ListProductCategories")
+func (m *MockBagelPayClient) ListProductCategories(ctx context.Context) ([]string, error) {
+	return result[[]string](m, "ListProductCategories")
+}
+
+// UpdateProduct returns the fixture configured with On("UpdateProduct")
+func (m *MockBagelPayClient) UpdateProduct(ctx context.Context, request bagelpay.UpdateProductRequest) (*bagelpay.Product, error) {
+	return result[*bagelpay.Product](m, "UpdateProduct")
+}
+
+// PatchProduct returns the fixture configured with On("PatchProduct")
+func (m *MockBagelPayClient) PatchProduct(ctx context.Context, request bagelpay.PatchProductRequest) (*bagelpay.Product, error) {
+	return result[*bagelpay.Product](m, "PatchProduct")
+}
+
+// DuplicateProduct returns the fixture configured with On("DuplicateProduct")
+func (m *MockBagelPayClient) DuplicateProduct(ctx context.Context, productID string, overrides *bagelpay.ProductOverrides) (*bagelpay.Product, error) {
+	return result[*bagelpay.Product](m, "DuplicateProduct")
+}
+
+// ArchiveProduct returns the fixture configured with On("ArchiveProduct")
+func (m *MockBagelPayClient) ArchiveProduct(ctx context.Context, productID string) (*bagelpay.Product, error) {
+	return result[*bagelpay.Product](m, "ArchiveProduct")
+}
+
+// UnarchiveProduct returns the fixture configured with On("UnarchiveProduct")
+func (m *MockBagelPayClient) UnarchiveProduct(ctx context.Context, productID string) (*bagelpay.Product, error) {
+	return result[*bagelpay.Product](m, "UnarchiveProduct")
+}
+
+// BulkArchiveProducts returns the fixture configured with On("BulkArchiveProducts")
+func (m *MockBagelPayClient) BulkArchiveProducts(ctx context.Context, productIDs []string) (*bagelpay.BulkOperationResult, error) {
+	return result[*bagelpay.BulkOperationResult](m, "BulkArchiveProducts")
+}
+
+// BulkUnarchiveProducts returns the fixture configured with On("BulkUnarchiveProducts")
+func (m *MockBagelPayClient) BulkUnarchiveProducts(ctx context.Context, productIDs []string) (*bagelpay.BulkOperationResult, error) {
+	return result[*bagelpay.BulkOperationResult](m, "BulkUnarchiveProducts")
+}
+
+// ListTransactions returns the fixture configured with On("ListTransactions")
+func (m *MockBagelPayClient) ListTransactions(ctx context.Context, filter bagelpay.TransactionFilter, pageNum, pageSize int) (*bagelpay.TransactionListResponse, error) {
+	return result[*bagelpay.TransactionListResponse](m, "ListTransactions")
+}
+
+// ListProductTransactions returns the fixture configured with On("ListProductTransactions")
+func (m *MockBagelPayClient) ListProductTransactions(ctx context.Context, productID string, pageNum, pageSize int) (*bagelpay.TransactionListResponse, error) {
+	return result[*bagelpay.TransactionListResponse](m, "ListProductTransactions")
+}
+
+// ListSubscriptionTransactions returns the fixture configured with On("ListSubscriptionTransactions")
+func (m *MockBagelPayClient) ListSubscriptionTransactions(ctx context.Context, subscriptionID string, pageNum, pageSize int) (*bagelpay.TransactionListResponse, error) {
+	return result[*bagelpay.TransactionListResponse](m, "ListSubscriptionTransactions")
+}
+
+// GetTransaction returns the fixture configured with On("GetTransaction")
+func (m *MockBagelPayClient) GetTransaction(ctx context.Context, transactionID string) (*bagelpay.Transaction, error) {
+	return result[*bagelpay.Transaction](m, "GetTransaction")
+}
+
+// CreateRefund returns the fixture configured with On("CreateRefund")
+func (m *MockBagelPayClient) CreateRefund(ctx context.Context, request bagelpay.RefundRequest) (*bagelpay.Refund, error) {
+	return result[*bagelpay.Refund](m, "CreateRefund")
+}
+
+// ListRefunds returns the fixture configured with On("ListRefunds")
+func (m *MockBagelPayClient) ListRefunds(ctx context.Context, transactionID string, pageNum, pageSize int) (*bagelpay.RefundListResponse, error) {
+	return result[*bagelpay.RefundListResponse](m, "ListRefunds")
+}
+
+// ListDisputes returns the fixture configured with On("ListDisputes")
+func (m *MockBagelPayClient) ListDisputes(ctx context.Context, filter bagelpay.DisputeFilter, pageNum, pageSize int) (*bagelpay.DisputeListResponse, error) {
+	return result[*bagelpay.DisputeListResponse](m, "ListDisputes")
+}
+
+// GetDispute returns the fixture configured with On("GetDispute")
+func (m *MockBagelPayClient) GetDispute(ctx context.Context, disputeID string) (*bagelpay.Dispute, error) {
+	return result[*bagelpay.Dispute](m, "GetDispute")
+}
+
+// SubmitDisputeEvidence returns the fixture configured with On("SubmitDisputeEvidence")
+func (m *MockBagelPayClient) SubmitDisputeEvidence(ctx context.Context, disputeID string, evidence bagelpay.DisputeEvidence) (*bagelpay.Dispute, error) {
+	return result[*bagelpay.Dispute](m, "SubmitDisputeEvidence")
+}
+
+// ListInvoices returns the fixture configured with On("ListInvoices")
+func (m *MockBagelPayClient) ListInvoices(ctx context.Context, filter bagelpay.InvoiceFilter, pageNum, pageSize int) (*bagelpay.InvoiceListResponse, error) {
+	return result[*bagelpay.InvoiceListResponse](m, "ListInvoices")
+}
+
+// ListSubscriptionInvoices returns the fixture configured with On("ListSubscriptionInvoices")
+func (m *MockBagelPayClient) ListSubscriptionInvoices(ctx context.Context, subscriptionID string, pageNum, pageSize int) (*bagelpay.InvoiceListResponse, error) {
+	return result[*bagelpay.InvoiceListResponse](m, "ListSubscriptionInvoices")
+}
+
+// GetInvoice returns the fixture configured with On("GetInvoice")
+func (m *MockBagelPayClient) GetInvoice(ctx context.Context, invoiceID string) (*bagelpay.Invoice, error) {
+	return result[*bagelpay.Invoice](m, "GetInvoice")
+}
+
+// ListSubscriptions returns the fixture configured with On("ListSubscriptions")
+func (m *MockBagelPayClient) ListSubscriptions(ctx context.Context, filter bagelpay.SubscriptionFilter, pageNum, pageSize int) (*bagelpay.SubscriptionListResponse, error) {
+	return result[*bagelpay.SubscriptionListResponse](m, "ListSubscriptions")
+}
+
+// GetCustomerSubscriptions returns the fixture configured with On("GetCustomerSubscriptions")
+func (m *MockBagelPayClient) GetCustomerSubscriptions(ctx context.Context, customerEmail string, pageNum, pageSize int) (*bagelpay.SubscriptionListResponse, error) {
+	return result[*bagelpay.SubscriptionListResponse](m, "GetCustomerSubscriptions")
+}
+
+// GetSubscription returns the fixture configured with On("GetSubscription")
+func (m *MockBagelPayClient) GetSubscription(ctx context.Context, subscriptionID string) (*bagelpay.Subscription, error) {
+	return result[*bagelpay.Subscription](m, "GetSubscription")
+}
+
+// CancelSubscription returns the fixture configured with On("CancelSubscription")
+func (m *MockBagelPayClient) CancelSubscription(ctx context.Context, subscriptionID string, opts bagelpay.CancelSubscriptionOptions) (*bagelpay.Subscription, error) {
+	return result[*bagelpay.Subscription](m, "CancelSubscription")
+}
+
+// BulkCancelSubscriptions returns the fixture configured with On("BulkCancelSubscriptions")
+func (m *MockBagelPayClient) BulkCancelSubscriptions(ctx context.Context, subscriptionIDs []string, opts bagelpay.CancelSubscriptionOptions) (*bagelpay.BulkOperationResult, error) {
+	return result[*bagelpay.BulkOperationResult](m, "BulkCancelSubscriptions")
+}
+
+// ReactivateSubscription returns the fixture configured with On("ReactivateSubscription")
+func (m *MockBagelPayClient) ReactivateSubscription(ctx context.Context, subscriptionID string) (*bagelpay.Subscription, error) {
+	return result[*bagelpay.Subscription](m, "ReactivateSubscription")
+}
+
+// PauseSubscription returns the fixture configured with On("PauseSubscription")
+func (m *MockBagelPayClient) PauseSubscription(ctx context.Context, subscriptionID string, request bagelpay.PauseRequest) (*bagelpay.Subscription, error) {
+	return result[*bagelpay.Subscription](m, "PauseSubscription")
+}
+
+// ResumeSubscription returns the fixture configured with On("ResumeSubscription")
+func (m *MockBagelPayClient) ResumeSubscription(ctx context.Context, subscriptionID string) (*bagelpay.Subscription, error) {
+	return result[*bagelpay.Subscription](m, "ResumeSubscription")
+}
+
+// ChangeSubscriptionPlan returns the fixture configured with On("ChangeSubscriptionPlan")
+func (m *MockBagelPayClient) ChangeSubscriptionPlan(ctx context.Context, subscriptionID string, request bagelpay.ChangePlanRequest) (*bagelpay.Subscription, error) {
+	return result[*bagelpay.Subscription](m, "ChangeSubscriptionPlan")
+}
+
+// PreviewChangePlan returns the fixture configured with On("PreviewChangePlan")
+func (m *MockBagelPayClient) PreviewChangePlan(ctx context.Context, subscriptionID string, request bagelpay.ChangePlanRequest) (*bagelpay.ProrationPreview, error) {
+	return result[*bagelpay.ProrationPreview](m, "PreviewChangePlan")
+}
+
+// ExtendTrial returns the fixture configured with On("ExtendTrial")
+func (m *MockBagelPayClient) ExtendTrial(ctx context.Context, subscriptionID string, additionalDays int) (*bagelpay.Subscription, error) {
+	return result[*bagelpay.Subscription](m, "ExtendTrial")
+}
+
+// ListCustomers returns the fixture configured with On("ListCustomers")
+func (m *MockBagelPayClient) ListCustomers(ctx context.Context, filter bagelpay.CustomerFilter, pageNum, pageSize int) (*bagelpay.CustomerListResponse, error) {
+	return result[*bagelpay.CustomerListResponse](m, "ListCustomers")
+}
+
+// SearchCustomers returns the fixture configured with On("SearchCustomers")
+func (m *MockBagelPayClient) SearchCustomers(ctx context.Context, query string, pageNum, pageSize int) (*bagelpay.CustomerListResponse, error) {
+	return result[*bagelpay.CustomerListResponse](m, "SearchCustomers")
+}
+
+// GetCustomer returns the fixture configured with On("GetCustomer")
+func (m *MockBagelPayClient) GetCustomer(ctx context.Context, customerID int) (*bagelpay.CustomerData, error) {
+	return result[*bagelpay.CustomerData](m, "GetCustomer")
+}
+
+// CreateCustomer returns the fixture configured with On("CreateCustomer")
+func (m *MockBagelPayClient) CreateCustomer(ctx context.Context, request bagelpay.CreateCustomerRequest) (*bagelpay.CustomerData, error) {
+	return result[*bagelpay.CustomerData](m, "CreateCustomer")
+}
+
+// UpdateCustomer returns the fixture configured with On("UpdateCustomer")
+func (m *MockBagelPayClient) UpdateCustomer(ctx context.Context, request bagelpay.UpdateCustomerRequest) (*bagelpay.CustomerData, error) {
+	return result[*bagelpay.CustomerData](m, "UpdateCustomer")
+}
+
+// DeleteCustomer returns the fixture configured with On("DeleteCustomer")
+func (m *MockBagelPayClient) DeleteCustomer(ctx context.Context, customerID int) error {
+	return errorResult(m, "DeleteCustomer")
+}
+
+// CreateCustomerPortalSession returns the fixture configured with On("CreateCustomerPortalSession")
+func (m *MockBagelPayClient) CreateCustomerPortalSession(ctx context.Context, customerID int, returnURL string) (*bagelpay.CustomerPortalSession, error) {
+	return result[*bagelpay.CustomerPortalSession](m, "CreateCustomerPortalSession")
+}
+
+// GetCustomerPortalURL returns the fixture configured with On("GetCustomerPortalURL")
+func (m *MockBagelPayClient) GetCustomerPortalURL(ctx context.Context, customerID int, returnURL string) (string, error) {
+	return result[string](m, "GetCustomerPortalURL")
+}
+
+// ListPaymentMethods returns the fixture configured with On("ListPaymentMethods")
+func (m *MockBagelPayClient) ListPaymentMethods(ctx context.Context, customerID int) ([]bagelpay.PaymentMethod, error) {
+	return result[[]bagelpay.PaymentMethod](m, "ListPaymentMethods")
+}
+
+// DeletePaymentMethod returns the fixture configured with On("DeletePaymentMethod")
+func (m *MockBagelPayClient) DeletePaymentMethod(ctx context.Context, paymentMethodID string) error {
+	return errorResult(m, "DeletePaymentMethod")
+}
+
+// SetDefaultPaymentMethod returns the fixture configured with On("SetDefaultPaymentMethod")
+func (m *MockBagelPayClient) SetDefaultPaymentMethod(ctx context.Context, customerID int, paymentMethodID string) error {
+	return errorResult(m, "SetDefaultPaymentMethod")
+}
+
+// ListSupportedPaymentMethods returns the fixture configured with On("ListSupportedPaymentMethods")
+func (m *MockBagelPayClient) ListSupportedPaymentMethods(ctx context.Context) ([]bagelpay.SupportedPaymentMethod, error) {
+	return result[[]bagelpay.SupportedPaymentMethod](m, "ListSupportedPaymentMethods")
+}
+
+// ListSupportedCurrencies returns the fixture configured with On("ListSupportedCurrencies")
+func (m *MockBagelPayClient) ListSupportedCurrencies(ctx context.Context) ([]bagelpay.Currency, error) {
+	return result[[]bagelpay.Currency](m, "ListSupportedCurrencies")
+}
+
+// ListTaxCategories returns the fixture configured with On("ListTaxCategories")
+func (m *MockBagelPayClient) ListTaxCategories(ctx context.Context, country *string) ([]bagelpay.TaxCategory, error) {
+	return result[[]bagelpay.TaxCategory](m, "ListTaxCategories")
+}
+
+// GetTaxRate returns the fixture configured with On("GetTaxRate")
+func (m *MockBagelPayClient) GetTaxRate(ctx context.Context, request bagelpay.TaxRateRequest) (*bagelpay.TaxRate, error) {
+	return result[*bagelpay.TaxRate](m, "GetTaxRate")
+}
+
+// CreateWebhook returns the fixture configured with On("CreateWebhook")
+func (m *MockBagelPayClient) CreateWebhook(ctx context.Context, request bagelpay.WebhookRequest) (*bagelpay.Webhook, error) {
+	return result[*bagelpay.Webhook](m, "CreateWebhook")
+}
+
+// ListWebhooks returns the fixture configured with On("ListWebhooks")
+func (m *MockBagelPayClient) ListWebhooks(ctx context.Context) ([]bagelpay.Webhook, error) {
+	return result[[]bagelpay.Webhook](m, "ListWebhooks")
+}
+
+// DeleteWebhook returns the fixture configured with On("DeleteWebhook")
+func (m *MockBagelPayClient) DeleteWebhook(ctx context.Context, webhookID string) error {
+	return errorResult(m, "DeleteWebhook")
+}
+
+// TriggerTestWebhook returns the fixture configured with On("TriggerTestWebhook")
+func (m *MockBagelPayClient) TriggerTestWebhook(ctx context.Context, webhookID string, eventType string) error {
+	return errorResult(m, "TriggerTestWebhook")
+}
+
+// ListWebhookEvents returns the fixture configured with On("ListWebhookEvents")
+func (m *MockBagelPayClient) ListWebhookEvents(ctx context.Context, webhookID string, pageNum, pageSize int) (*bagelpay.WebhookEventListResponse, error) {
+	return result[*bagelpay.WebhookEventListResponse](m, "ListWebhookEvents")
+}
+
+// GetWebhookEvent returns the fixture configured with On("GetWebhookEvent")
+func (m *MockBagelPayClient) GetWebhookEvent(ctx context.Context, eventID string) (*bagelpay.WebhookEvent, error) {
+	return result[*bagelpay.WebhookEvent](m, "GetWebhookEvent")
+}
+
+// ResendWebhookEvent returns the fixture configured with On("ResendWebhookEvent")
+func (m *MockBagelPayClient) ResendWebhookEvent(ctx context.Context, eventID string) error {
+	return errorResult(m, "ResendWebhookEvent")
+}
+
+// GetRevenueSummary returns the fixture configured with On("GetRevenueSummary")
+func (m *MockBagelPayClient) GetRevenueSummary(ctx context.Context, period string) (*bagelpay.RevenueSummary, error) {
+	return result[*bagelpay.RevenueSummary](m, "GetRevenueSummary")
+}
+
+// GetBalance returns the fixture configured with On("GetBalance")
+func (m *MockBagelPayClient) GetBalance(ctx context.Context) (*bagelpay.Balance, error) {
+	return result[*bagelpay.Balance](m, "GetBalance")
+}
+
+// ListPayouts returns the fixture configured with On("ListPayouts")
+func (m *MockBagelPayClient) ListPayouts(ctx context.Context, pageNum, pageSize int) (*bagelpay.PayoutListResponse, error) {
+	return result[*bagelpay.PayoutListResponse](m, "ListPayouts")
+}
+
+// GetPayout returns the fixture configured with On("GetPayout")
+func (m *MockBagelPayClient) GetPayout(ctx context.Context, payoutID string) (*bagelpay.Payout, error) {
+	return result[*bagelpay.Payout](m, "GetPayout")
+}
+
+// GetStoreInfo returns the fixture configured with On("GetStoreInfo")
+func (m *MockBagelPayClient) GetStoreInfo(ctx context.Context) (*bagelpay.StoreInfo, error) {
+	return result[*bagelpay.StoreInfo](m, "GetStoreInfo")
+}
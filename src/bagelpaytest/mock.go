@@ -0,0 +1,152 @@
+/*
+Package bagelpaytest provides a mock BagelPay client for unit tests that must not
+make network calls.
+
+Example usage:
+
+	func TestCheckout(t *testing.T) {
+		mock := bagelpaytest.NewMockBagelPayClient()
+		mock.On("CreateCheckout").Return(&bagelpay.CheckoutResponse{
+			CheckoutURL: bagelpay.StringPtr("https://test.bagelpay.io/checkout/123"),
+		}, nil)
+
+		// Code under test accepts a bagelpay.BagelPayClientInterface
+		startCheckout(mock)
+
+		mock.AssertExpectations(t)
+	}
+*/
+package bagelpaytest
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/bagelpay/bagelpay-sdk-go/src/bagelpay"
+)
+
+// Call holds the fixture returned by a mocked method
+type Call struct {
+	Method string
+
+	value     interface{}
+	err       error
+	callCount int
+}
+
+// Return sets the value and error returned by the mocked method.
+// For methods that only return an error, pass nil as the value.
+func (c *Call) Return(v interface{}, err error) *Call {
+	c.value = v
+	c.err = err
+	return c
+}
+
+// MockBagelPayClient is a bagelpay.BagelPayClientInterface that returns pre-configured fixtures
+type MockBagelPayClient struct {
+	mu         sync.Mutex
+	calls      map[string]*Call
+	unexpected []string
+}
+
+var _ bagelpay.BagelPayClientInterface = (*MockBagelPayClient)(nil)
+
+// NewMockBagelPayClient creates a mock client with no expectations
+func NewMockBagelPayClient() *MockBagelPayClient {
+	return &MockBagelPayClient{
+		calls: make(map[string]*Call),
+	}
+}
+
+// On registers an expectation for the named method and returns its Call
+func (m *MockBagelPayClient) On(method string) *Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	call := &Call{Method: method}
+	m.calls[method] = call
+	return call
+}
+
+// CallCount returns how many times the named method has been called
+func (m *MockBagelPayClient) CallCount(method string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if call, ok := m.calls[method]; ok {
+		return call.callCount
+	}
+	return 0
+}
+
+// AssertExpectations fails the test if an expected method was never called
+// or a method was called without an expectation
+func (m *MockBagelPayClient) AssertExpectations(t testing.TB) bool {
+	t.Helper()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	methods := make([]string, 0, len(m.calls))
+	for method := range m.calls {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+
+	ok := true
+	for _, method := range methods {
+		if m.calls[method].callCount == 0 {
+			t.Errorf("bagelpaytest: expected call to %s was not made", method)
+			ok = false
+		}
+	}
+	for _, method := range m.unexpected {
+		t.Errorf("bagelpaytest: unexpected call to %s", method)
+		ok = false
+	}
+	return ok
+}
+
+// called records a call to the named method and returns its fixture
+func (m *MockBagelPayClient) called(method string) (*Call, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	call, ok := m.calls[method]
+	if !ok {
+		m.unexpected = append(m.unexpected, method)
+		return nil, bagelpay.NewBagelPayError(fmt.Sprintf("bagelpaytest: no return value configured for %s", method), nil)
+	}
+	call.callCount++
+	return call, nil
+}
+
+// result returns the fixture for the named method converted to T
+func result[T any](m *MockBagelPayClient, method string) (T, error) {
+	var zero T
+
+	call, err := m.called(method)
+	if err != nil {
+		return zero, err
+	}
+	if call.value == nil {
+		return zero, call.err
+	}
+
+	value, ok := call.value.(T)
+	if !ok {
+		return zero, bagelpay.NewBagelPayError(fmt.Sprintf("bagelpaytest: %s fixture has type %T, want %T", method, call.value, zero), nil)
+	}
+	return value, call.err
+}
+
+// errorResult returns the fixture error for a method that only returns an error
+func errorResult(m *MockBagelPayClient, method string) error {
+	call, err := m.called(method)
+	if err != nil {
+		return err
+	}
+	return call.err
+}
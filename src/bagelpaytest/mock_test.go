@@ -0,0 +1,110 @@
+package bagelpaytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bagelpay/bagelpay-sdk-go/src/bagelpay"
+)
+
+// fakeTB records the failures reported to it instead of failing the running test
+type fakeTB struct {
+	testing.TB
+	errors []string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func TestMockReturnsFixture(t *testing.T) {
+	mock := NewMockBagelPayClient()
+	want := &bagelpay.Product{ProductID: bagelpay.StringPtr("prod_1")}
+	mock.On("GetProduct").Return(want, nil)
+	mock.On("ListProductCategories").Return([]string{"books"}, nil)
+
+	product, err := mock.GetProduct(context.Background(), "prod_1")
+	if err != nil {
+		t.Fatalf("GetProduct: %v", err)
+	}
+	if product != want {
+		t.Errorf("GetProduct = %+v, want the fixture %+v", product, want)
+	}
+	categories, err := mock.ListProductCategories(context.Background())
+	if err != nil || !reflect.DeepEqual(categories, []string{"books"}) {
+		t.Errorf("ListProductCategories = %v, %v, want [books], nil", categories, err)
+	}
+	if n := mock.CallCount("GetProduct"); n != 1 {
+		t.Errorf("CallCount(GetProduct) = %d, want 1", n)
+	}
+	if !mock.AssertExpectations(t) {
+		t.Error("AssertExpectations = false with every expectation met")
+	}
+}
+
+func TestMockReturnsFixtureError(t *testing.T) {
+	mock := NewMockBagelPayClient()
+	wantErr := errors.New("boom")
+	mock.On("GetProduct").Return(nil, wantErr)
+	mock.On("Ping").Return(nil, wantErr)
+
+	if product, err := mock.GetProduct(context.Background(), "prod_1"); product != nil || err != wantErr {
+		t.Errorf("GetProduct = %v, %v, want nil, %v", product, err, wantErr)
+	}
+	if err := mock.Ping(context.Background()); err != wantErr {
+		t.Errorf("Ping = %v, want %v", err, wantErr)
+	}
+}
+
+func TestMockWrongFixtureType(t *testing.T) {
+	mock := NewMockBagelPayClient()
+	mock.On("GetProduct").Return(&bagelpay.Coupon{}, nil)
+
+	product, err := mock.GetProduct(context.Background(), "prod_1")
+	if err == nil {
+		t.Fatal("GetProduct accepted a *bagelpay.Coupon fixture")
+	}
+	if product != nil {
+		t.Errorf("GetProduct = %+v, want nil with the error", product)
+	}
+	if !strings.Contains(err.Error(), "*bagelpay.Coupon") || !strings.Contains(err.Error(), "*bagelpay.Product") {
+		t.Errorf("error = %q, want it to name both types", err)
+	}
+}
+
+func TestMockUnexpectedCall(t *testing.T) {
+	mock := NewMockBagelPayClient()
+
+	if _, err := mock.GetStoreInfo(context.Background()); err == nil {
+		t.Error("GetStoreInfo without an expectation returned no error")
+	}
+
+	tb := &fakeTB{}
+	if mock.AssertExpectations(tb) {
+		t.Error("AssertExpectations = true after an unexpected call")
+	}
+	if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], "unexpected call to GetStoreInfo") {
+		t.Errorf("reported %q, want one unexpected call to GetStoreInfo", tb.errors)
+	}
+}
+
+func TestMockAssertExpectationsUncalled(t *testing.T) {
+	mock := NewMockBagelPayClient()
+	mock.On("Ping").Return(nil, nil)
+	mock.On("GetProduct").Return(&bagelpay.Product{}, nil)
+	mock.Ping(context.Background())
+
+	tb := &fakeTB{}
+	if mock.AssertExpectations(tb) {
+		t.Error("AssertExpectations = true with GetProduct never called")
+	}
+	if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], "expected call to GetProduct was not made") {
+		t.Errorf("reported %q, want one missing call to GetProduct", tb.errors)
+	}
+}